@@ -115,9 +115,8 @@ func main() {
 				)
 			}
 
-			// Log audit trail
+			// Log resource access (command execution itself is audited automatically)
 			if audit := ctx.AuditLogger(); audit != nil {
-				audit.LogCommand(context.Background(), "process", ctx.Args, "demo-user")
 				audit.LogAccess(context.Background(), "data.txt", "read", true)
 			}
 
@@ -0,0 +1,151 @@
+// alias.go: command aliases and prefix matching in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AddAlias adds alternative names the command can be invoked with (e.g. "rm" for "remove").
+// Aliases are resolved like the command name, shown in help and offered by completion.
+func (c *Command) AddAlias(aliases ...string) *Command {
+	c.aliases = append(c.aliases, aliases...)
+	return c
+}
+
+// Aliases returns the alternative names of the command.
+func (c *Command) Aliases() []string {
+	return c.aliases
+}
+
+// hasName reports whether name is the command name or one of its aliases.
+func (c *Command) hasName(name string) bool {
+	if c.name == name {
+		return true
+	}
+	for _, alias := range c.aliases {
+		if alias == name {
+			return true
+		}
+	}
+	return false
+}
+
+// names returns the command name followed by its aliases.
+func (c *Command) names() []string {
+	return append([]string{c.name}, c.aliases...)
+}
+
+// SetPrefixMatching enables dispatching commands and subcommands by an unambiguous
+// prefix of their name or alias (e.g. "sta" for "status"). Disabled by default.
+func (app *App) SetPrefixMatching(enabled bool) *App {
+	app.prefixMatching = enabled
+	return app
+}
+
+// resolveCommand finds a top-level command by name, alias or unambiguous prefix.
+// The built-in help command resolves to app.helpCommand.
+func (app *App) resolveCommand(name string) (*Command, error) {
+	commands := make([]*Command, 0, len(app.commands)+1)
+	for _, cmd := range app.commands {
+		commands = append(commands, cmd)
+	}
+	commands = append(commands, app.helpCommand)
+
+	cmd, candidates := matchCommand(name, commands, app.prefixMatching)
+	if cmd != nil {
+		return cmd, nil
+	}
+	if len(candidates) > 0 {
+		return nil, ambiguousCommandError(name, name, candidates)
+	}
+	return nil, app.unknownCommandError(name)
+}
+
+// resolveSubcommand finds a subcommand by name, alias or (when enabled) unambiguous prefix.
+func (c *Command) resolveSubcommand(app *App, name string) (*Command, error) {
+	commands := make([]*Command, 0, len(c.subcommands))
+	for _, subcmd := range c.subcommands {
+		commands = append(commands, subcmd)
+	}
+
+	prefix := app != nil && app.prefixMatching
+	subcmd, candidates := matchCommand(name, commands, prefix)
+	if subcmd != nil {
+		return subcmd, nil
+	}
+	if len(candidates) > 0 {
+		return nil, ambiguousCommandError(c.name+" "+name, name, candidates)
+	}
+
+	if c.unknownSubcommand != nil {
+		return nil, c.unknownSubcommand(name)
+	}
+
+	var names []string
+	for _, subcmd := range visibleCommands(c.subcommands) {
+		names = append(names, subcmd.names()...)
+	}
+	err := NotFoundError(c.name+" "+name, fmt.Sprintf("unknown subcommand '%s' for command '%s'", name, c.name))
+	return nil, app.suggestCommand(err, "subcommand", name, names)
+}
+
+// matchCommand returns the command whose name or alias equals name. With prefix
+// matching enabled, a prefix matching exactly one command is accepted; when it
+// matches several commands their sorted names are returned as candidates.
+// Hidden commands only match by their exact name or alias.
+func matchCommand(name string, commands []*Command, prefix bool) (*Command, []string) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, nil
+		}
+	}
+	for _, cmd := range commands {
+		if cmd.hasName(name) {
+			return cmd, nil
+		}
+	}
+	if !prefix || name == "" {
+		return nil, nil
+	}
+
+	var matches []*Command
+	for _, cmd := range commands {
+		if cmd.hidden {
+			continue
+		}
+		for _, candidate := range cmd.names() {
+			if strings.HasPrefix(candidate, name) {
+				matches = append(matches, cmd)
+				break
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return matches[0], nil
+	}
+
+	candidates := make([]string, len(matches))
+	for i, cmd := range matches {
+		candidates[i] = cmd.name
+	}
+	sort.Strings(candidates)
+	return nil, candidates
+}
+
+// ambiguousCommandError reports a prefix that matches several commands.
+func ambiguousCommandError(command, name string, candidates []string) *Error {
+	return ValidationError(command, fmt.Sprintf("ambiguous command '%s' could match: %s", name, strings.Join(candidates, ", "))).
+		WithUserMessage(fmt.Sprintf("Command '%s' is ambiguous, it could be one of: %s", name, strings.Join(candidates, ", "))).
+		WithContext("candidates", candidates)
+}
@@ -0,0 +1,138 @@
+// alias_test.go: tests for command aliases and prefix matching in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/agilira/orpheus/pkg/orpheus"
+)
+
+func newAliasApp(executed *string) *orpheus.App {
+	record := func(name string) orpheus.CommandHandler {
+		return func(ctx *orpheus.Context) error {
+			*executed = name
+			return nil
+		}
+	}
+
+	app := orpheus.New("myapp")
+	app.AddCommand(orpheus.NewCommand("remove", "Remove files").AddAlias("rm", "del").SetHandler(record("remove")))
+	app.AddCommand(orpheus.NewCommand("status", "Show status").SetHandler(record("status")))
+	app.AddCommand(orpheus.NewCommand("stash", "Stash changes").SetHandler(record("stash")))
+
+	remote := orpheus.NewCommand("remote", "Manage remotes")
+	remote.AddSubcommand(orpheus.NewCommand("remove", "Remove remote").AddAlias("rm").SetHandler(record("remote remove")))
+	remote.AddSubcommand(orpheus.NewCommand("rename", "Rename remote").SetHandler(record("remote rename")))
+	app.AddCommand(remote)
+	return app
+}
+
+func TestCommandAliases(t *testing.T) {
+	var executed string
+	app := newAliasApp(&executed)
+
+	for _, args := range [][]string{{"remove"}, {"rm"}, {"del"}} {
+		executed = ""
+		if err := app.Run(args); err != nil {
+			t.Fatalf("%v: unexpected error: %v", args, err)
+		}
+		if executed != "remove" {
+			t.Errorf("%v: expected remove to run, got %q", args, executed)
+		}
+	}
+
+	if err := app.Run([]string{"remote", "rm"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if executed != "remote remove" {
+		t.Errorf("expected remote remove, got %q", executed)
+	}
+
+	if got := app.GetCommands()["remote"].GetSubcommand("rm"); got == nil || got.Name() != "remove" {
+		t.Errorf("GetSubcommand should resolve aliases, got %v", got)
+	}
+}
+
+func TestAliasesInHelpAndCompletion(t *testing.T) {
+	var executed string
+	app := newAliasApp(&executed)
+
+	appHelp := app.GenerateHelp()
+	if strings.Count(appHelp, "Remove files") != 1 {
+		t.Errorf("aliased command should be listed once, got:\n%s", appHelp)
+	}
+
+	cmdHelp := app.GetHelpGenerator().GenerateCommandHelp(app.GetCommands()["remove"])
+	if !strings.Contains(cmdHelp, "Aliases: remove, rm, del") {
+		t.Errorf("command help should list aliases, got:\n%s", cmdHelp)
+	}
+
+	result := app.Complete([]string{"r"}, 1)
+	expected := []string{"remote", "remove", "rm"}
+	if !reflect.DeepEqual(result.Suggestions, expected) {
+		t.Errorf("expected %v, got %v", expected, result.Suggestions)
+	}
+
+	var out bytes.Buffer
+	app.SetOutput(&out)
+	if err := app.Run([]string{"__complete", "d"}); err != nil || out.String() != "del\tRemove files\n:0\n" {
+		t.Errorf("shell completion should include aliases, got %q (%v)", out.String(), err)
+	}
+}
+
+func TestPrefixMatching(t *testing.T) {
+	var executed string
+	app := newAliasApp(&executed)
+
+	// Prefixes are not matched unless enabled
+	var orpheusErr *orpheus.Error
+	if err := app.Run([]string{"statu"}); !errors.As(err, &orpheusErr) || !orpheusErr.IsNotFoundError() {
+		t.Fatalf("expected not found error without prefix matching, got %v", err)
+	}
+
+	app.SetPrefixMatching(true)
+	if err := app.Run([]string{"statu"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if executed != "status" {
+		t.Errorf("expected status to run, got %q", executed)
+	}
+
+	if err := app.Run([]string{"remote", "ren"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if executed != "remote rename" {
+		t.Errorf("expected remote rename to run, got %q", executed)
+	}
+
+	// Prefixes of aliases resolve to the aliased command
+	if err := app.Run([]string{"de"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if executed != "remove" {
+		t.Errorf("expected remove to run, got %q", executed)
+	}
+}
+
+func TestAmbiguousPrefix(t *testing.T) {
+	var executed string
+	app := newAliasApp(&executed).SetPrefixMatching(true)
+
+	err := app.Run([]string{"sta"})
+	requireValidationError(t, err, "ambiguous command 'sta' could match: stash, status")
+	if got := errorContext(t, err)["candidates"]; !reflect.DeepEqual(got, []string{"stash", "status"}) {
+		t.Errorf("unexpected candidates: %v", got)
+	}
+
+	err = app.Run([]string{"remote", "re"})
+	requireValidationError(t, err, "could match: remove, rename")
+}
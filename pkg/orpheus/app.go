@@ -32,35 +32,63 @@ package orpheus
 import (
 	"context"
 	"fmt"
+	"io"
 	"strings"
+	"text/template"
 
 	flashflags "github.com/agilira/flash-flags"
 )
 
 // App represents the main CLI application.
 type App struct {
-	name             string
-	description      string
-	version          string
-	commands         map[string]*Command
-	globalFlags      *flashflags.FlagSet
-	defaultCmd       string
-	helpCommand      *Command
-	logger           Logger
-	auditLogger      AuditLogger
-	tracer           Tracer
-	metricsCollector MetricsCollector
-	storage          Storage
-	storageConfig    *StorageConfig
-	pluginManager    *PluginManager
+	name                   string
+	description            string
+	version                string
+	commands               map[string]*Command
+	globalFlags            *flashflags.FlagSet
+	globalFlagMeta         *flagMeta
+	globalSources          map[string]FlagSource
+	envPrefix              string
+	inputValidator         *InputValidator
+	suggestionsDisabled    bool
+	suggestionDistance     int
+	prefixMatching         bool
+	commandGroups          []string
+	helpTemplate           string
+	usageTemplate          string
+	templates              *template.Template // compiled custom help templates
+	templateErr            error
+	middleware             []Middleware
+	runCtx                 context.Context
+	signalHandlingDisabled bool
+	exitFunc               func(code int)
+	errOutput              io.Writer
+	output                 io.Writer
+	helpWidth              int
+	colorMode              ColorMode
+	noColorArg             bool
+	configEnabled          bool
+	configPaths            []string
+	configFiles            []string
+	configValues           map[string]interface{}
+	defaultCmd             string
+	helpCommand            *Command
+	logger                 Logger
+	auditLogger            AuditLogger
+	tracer                 Tracer
+	metricsCollector       MetricsCollector
+	storage                Storage
+	storageConfig          *StorageConfig
+	pluginManager          *PluginManager
 }
 
 // New creates a new Orpheus application.
 func New(name string) *App {
 	app := &App{
-		name:        name,
-		commands:    make(map[string]*Command),
-		globalFlags: flashflags.New(name),
+		name:           name,
+		commands:       make(map[string]*Command),
+		globalFlags:    flashflags.New(name),
+		globalFlagMeta: newFlagMeta(),
 	}
 
 	// Add built-in help command
@@ -274,12 +302,31 @@ func (app *App) SetDefaultCommand(cmdName string) *App {
 }
 
 // Run executes the application with the given arguments.
+// It is equivalent to RunContext(context.Background(), args).
 func (app *App) Run(args []string) error {
+	return app.RunContext(context.Background(), args)
+}
+
+// run dispatches the arguments to the matching command.
+func (app *App) run(args []string) error {
+	if app.templateErr != nil {
+		return app.templateErr
+	}
+	app.noColorArg = hasNoColorArg(args)
+
 	// Handle empty args
 	if len(args) == 0 {
+		if err := app.parseGlobalFlags(nil); err != nil {
+			return err
+		}
 		return app.handleEmptyArgs()
 	}
 
+	// Completion scripts call back into the binary with the raw command line
+	if args[0] == completeCommandName {
+		return app.runComplete(args[1:])
+	}
+
 	// Handle built-in flags
 	if handled, err := app.handleBuiltinFlags(args); handled {
 		return err
@@ -287,20 +334,50 @@ func (app *App) Run(args []string) error {
 
 	// Parse global flags and get command
 	globalArgs, cmdArgs := app.splitGlobalArgs(args)
-	if err := app.globalFlags.Parse(globalArgs); err != nil {
-		return ValidationError("", "global flag parsing failed: "+err.Error())
+	if err := app.parseGlobalFlags(globalArgs); err != nil {
+		return err
 	}
 
 	// Handle command execution
 	return app.handleCommandExecution(cmdArgs)
 }
 
+// parseGlobalFlags parses global flags from argv and fills unset ones from the environment.
+func (app *App) parseGlobalFlags(globalArgs []string) error {
+	app.globalFlags.Reset()
+	if err := app.globalFlags.Parse(app.globalFlagMeta.expandShorthands(globalArgs)); err != nil {
+		return app.flagParseError("", "global flag parsing failed", app.globalFlags, err)
+	}
+
+	binding := &flagBinding{
+		app:     app,
+		flags:   app.globalFlags,
+		meta:    app.globalFlagMeta,
+		sources: make(map[string]FlagSource),
+	}
+	binding.recordArgs()
+	if err := binding.applyEnvironment(); err != nil {
+		return err
+	}
+
+	// Configuration files are loaded after env so that --config can come from the environment
+	if err := app.loadConfig(); err != nil {
+		return err
+	}
+	if err := binding.applyConfig(); err != nil {
+		return err
+	}
+	app.globalSources = binding.sources
+
+	return nil
+}
+
 // handleEmptyArgs handles the case when no arguments are provided.
 func (app *App) handleEmptyArgs() error {
 	if app.defaultCmd != "" {
 		return app.runCommand(app.defaultCmd, []string{})
 	}
-	return app.helpHandler(&Context{App: app, storage: app.storage})
+	return app.helpHandler(&Context{App: app, storage: app.storage, stdCtx: app.runContext()})
 }
 
 // handleBuiltinFlags handles built-in flags like --help and --version.
@@ -309,7 +386,7 @@ func (app *App) handleBuiltinFlags(args []string) (handled bool, err error) {
 
 	// Check for global help flag
 	if firstArg == "--help" || firstArg == "-h" {
-		return true, app.helpHandler(&Context{App: app, storage: app.storage})
+		return true, app.helpHandler(&Context{App: app, storage: app.storage, stdCtx: app.runContext()})
 	}
 
 	// Check for version flag
@@ -324,9 +401,9 @@ func (app *App) handleBuiltinFlags(args []string) (handled bool, err error) {
 // printVersion prints the application version.
 func (app *App) printVersion() {
 	if app.version != "" {
-		fmt.Printf("%s version %s\n", app.name, app.version)
+		fmt.Fprintf(app.stdout(), "%s version %s\n", app.name, app.version)
 	} else {
-		fmt.Printf("%s (no version set)\n", app.name)
+		fmt.Fprintf(app.stdout(), "%s (no version set)\n", app.name)
 	}
 }
 
@@ -337,15 +414,18 @@ func (app *App) handleCommandExecution(cmdArgs []string) error {
 		return app.handleEmptyArgs()
 	}
 
-	cmdName := cmdArgs[0]
+	cmd, err := app.resolveCommand(cmdArgs[0])
+	if err != nil {
+		return err
+	}
 	cmdArgs = cmdArgs[1:] // Remove command name from args
 
 	// Handle built-in help command
-	if cmdName == "help" {
+	if cmd == app.helpCommand {
 		return app.handleHelpCommand(cmdArgs)
 	}
 
-	return app.runCommand(cmdName, cmdArgs)
+	return app.runCommand(cmd.name, cmdArgs)
 }
 
 // handleHelpCommand handles the built-in help command.
@@ -353,22 +433,24 @@ func (app *App) handleHelpCommand(cmdArgs []string) error {
 	if len(cmdArgs) > 0 {
 		return app.showCommandHelp(cmdArgs[0])
 	}
-	return app.helpHandler(&Context{App: app, storage: app.storage})
+	return app.helpHandler(&Context{App: app, storage: app.storage, stdCtx: app.runContext()})
 }
 
 // runCommand executes a specific command.
 func (app *App) runCommand(cmdName string, args []string) error {
-	cmd, exists := app.commands[cmdName]
-	if !exists {
-		return NotFoundError(cmdName, fmt.Sprintf("command '%s' not found", cmdName))
+	cmd, err := app.resolveCommand(cmdName)
+	if err != nil {
+		return err
 	}
 
 	// Create execution context
 	ctx := &Context{
-		App:         app,
-		Args:        args,
-		GlobalFlags: app.globalFlags,
-		storage:     app.storage,
+		App:           app,
+		Args:          args,
+		GlobalFlags:   app.globalFlags,
+		storage:       app.storage,
+		globalSources: app.globalSources,
+		stdCtx:        app.runContext(),
 	}
 
 	// Execute the command
@@ -472,19 +554,19 @@ func (app *App) isShortBooleanFlag(arg string) bool {
 } // helpHandler handles the help command.
 func (app *App) helpHandler(ctx *Context) error {
 	generator := NewHelpGenerator(app)
-	fmt.Printf("%s", generator.GenerateAppHelp())
+	fmt.Fprint(app.stdout(), generator.GenerateAppHelp())
 	return nil
 }
 
 // showCommandHelp shows help for a specific command.
 func (app *App) showCommandHelp(cmdName string) error {
-	cmd, exists := app.commands[cmdName]
-	if !exists {
-		return NotFoundError(cmdName, fmt.Sprintf("command '%s' not found", cmdName))
+	cmd, err := app.resolveCommand(cmdName)
+	if err != nil {
+		return err
 	}
 
 	generator := NewHelpGenerator(app)
-	fmt.Printf("%s", generator.GenerateCommandHelp(cmd))
+	fmt.Fprint(app.stdout(), generator.GenerateCommandHelp(cmd))
 	return nil
 }
 
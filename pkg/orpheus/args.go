@@ -0,0 +1,250 @@
+// args.go: declarative positional arguments in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ArgType represents the type of a positional argument.
+type ArgType int
+
+const (
+	// ArgString accepts any value as-is
+	ArgString ArgType = iota
+	// ArgInt accepts integer values
+	ArgInt
+	// ArgFloat64 accepts floating point values
+	ArgFloat64
+	// ArgBool accepts boolean values (true/false, 1/0, ...)
+	ArgBool
+	// ArgDuration accepts Go duration values (e.g. "30s", "5m")
+	ArgDuration
+)
+
+// String returns the human-readable name of the argument type.
+func (t ArgType) String() string {
+	switch t {
+	case ArgInt:
+		return "int"
+	case ArgFloat64:
+		return "float64"
+	case ArgBool:
+		return "bool"
+	case ArgDuration:
+		return "duration"
+	default:
+		return "string"
+	}
+}
+
+// ArgSpec describes a named positional argument accepted by a command.
+type ArgSpec struct {
+	// Name is the argument name used in usage output and by Context.Arg
+	Name string
+	// Description is shown in the Arguments section of the command help
+	Description string
+	// Type is the type the raw value is converted to
+	Type ArgType
+	// Required makes the command fail when the argument is missing
+	Required bool
+	// Variadic collects all remaining positional values (must be the last argument)
+	Variadic bool
+	// Validator is an optional check run on each raw value before conversion
+	Validator func(value string) error
+}
+
+// AddArg adds a required string positional argument to the command.
+func (c *Command) AddArg(name, description string) *Command {
+	return c.AddArgSpec(ArgSpec{Name: name, Description: description, Required: true})
+}
+
+// AddOptionalArg adds an optional string positional argument to the command.
+func (c *Command) AddOptionalArg(name, description string) *Command {
+	return c.AddArgSpec(ArgSpec{Name: name, Description: description})
+}
+
+// AddVariadicArg adds a string positional argument collecting all remaining values.
+// When required is true at least one value must be provided.
+func (c *Command) AddVariadicArg(name, description string, required bool) *Command {
+	return c.AddArgSpec(ArgSpec{Name: name, Description: description, Required: required, Variadic: true})
+}
+
+// AddArgSpec adds a fully configured positional argument to the command.
+// Arguments are matched in the order they are declared.
+func (c *Command) AddArgSpec(spec ArgSpec) *Command {
+	c.args = append(c.args, spec)
+	return c
+}
+
+// ArgSpecs returns a copy of the declared positional arguments for introspection.
+func (c *Command) ArgSpecs() []ArgSpec {
+	specs := make([]ArgSpec, len(c.args))
+	copy(specs, c.args)
+	return specs
+}
+
+// HasArgSpecs returns true if the command declares positional arguments.
+func (c *Command) HasArgSpecs() bool {
+	return len(c.args) > 0
+}
+
+// argsUsage returns the usage fragment for the declared arguments (e.g. "<src> [dst...]").
+func (c *Command) argsUsage() string {
+	parts := make([]string, 0, len(c.args))
+	for _, spec := range c.args {
+		parts = append(parts, spec.usageName())
+	}
+	return strings.Join(parts, " ")
+}
+
+// usageName returns the argument name decorated for usage output.
+func (spec ArgSpec) usageName() string {
+	name := spec.Name
+	if spec.Variadic {
+		name += "..."
+	}
+	if spec.Required {
+		return "<" + name + ">"
+	}
+	return "[" + name + "]"
+}
+
+// validateArgSpecs checks that the declared arguments form a valid sequence.
+func (c *Command) validateArgSpecs() error {
+	seenOptional := false
+	for i, spec := range c.args {
+		if spec.Name == "" {
+			return InternalError(fmt.Sprintf("command '%s': argument %d has no name", c.name, i))
+		}
+		if spec.Variadic && i != len(c.args)-1 {
+			return InternalError(fmt.Sprintf("command '%s': variadic argument '%s' must be the last argument", c.name, spec.Name))
+		}
+		if spec.Required && seenOptional {
+			return InternalError(fmt.Sprintf("command '%s': required argument '%s' follows an optional argument", c.name, spec.Name))
+		}
+		if !spec.Required {
+			seenOptional = true
+		}
+	}
+	return nil
+}
+
+// bindArgs checks arity and converts positional values according to the declared arguments.
+// Commands without declared arguments accept any positional values unchanged.
+func (c *Command) bindArgs(positional []string) (map[string]interface{}, error) {
+	if !c.HasArgSpecs() {
+		return nil, nil
+	}
+
+	if err := c.validateArgSpecs(); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]interface{}, len(c.args))
+	for i, spec := range c.args {
+		if spec.Variadic {
+			var rest []string
+			if i < len(positional) {
+				rest = positional[i:]
+			}
+			if spec.Required && len(rest) == 0 {
+				return nil, c.missingArgError(spec)
+			}
+			converted := make([]interface{}, 0, len(rest))
+			for _, raw := range rest {
+				value, err := c.convertArg(spec, raw)
+				if err != nil {
+					return nil, err
+				}
+				converted = append(converted, value)
+			}
+			if len(converted) > 0 {
+				values[spec.Name] = converted
+			}
+			return values, nil
+		}
+
+		if i >= len(positional) {
+			if spec.Required {
+				return nil, c.missingArgError(spec)
+			}
+			continue
+		}
+
+		value, err := c.convertArg(spec, positional[i])
+		if err != nil {
+			return nil, err
+		}
+		values[spec.Name] = value
+	}
+
+	if len(positional) > len(c.args) {
+		extra := positional[len(c.args):]
+		return nil, ValidationError(c.name, fmt.Sprintf("too many arguments: expected at most %d, got %d", len(c.args), len(positional))).
+			WithUserMessage(fmt.Sprintf("Unexpected argument '%s'. Usage: %s", extra[0], c.Usage())).
+			WithContext("extra_args", extra)
+	}
+
+	return values, nil
+}
+
+// missingArgError creates the validation error for a missing required argument.
+func (c *Command) missingArgError(spec ArgSpec) *Error {
+	return ValidationError(c.name, fmt.Sprintf("missing required argument %s", spec.usageName())).
+		WithUserMessage(fmt.Sprintf("Missing required argument '%s'. Usage: %s", spec.Name, c.Usage())).
+		WithContext("argument", spec.Name)
+}
+
+// convertArg validates and converts a single raw argument value.
+func (c *Command) convertArg(spec ArgSpec, raw string) (interface{}, error) {
+	if spec.Validator != nil {
+		if err := spec.Validator(raw); err != nil {
+			return nil, c.invalidArgError(spec, raw, err.Error())
+		}
+	}
+
+	switch spec.Type {
+	case ArgInt:
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, c.invalidArgError(spec, raw, "expected an integer")
+		}
+		return value, nil
+	case ArgFloat64:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, c.invalidArgError(spec, raw, "expected a number")
+		}
+		return value, nil
+	case ArgBool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, c.invalidArgError(spec, raw, "expected a boolean")
+		}
+		return value, nil
+	case ArgDuration:
+		value, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, c.invalidArgError(spec, raw, "expected a duration")
+		}
+		return value, nil
+	default:
+		return raw, nil
+	}
+}
+
+// invalidArgError creates the validation error for an argument with an invalid value.
+func (c *Command) invalidArgError(spec ArgSpec, raw, reason string) *Error {
+	return ValidationError(c.name, fmt.Sprintf("invalid value '%s' for argument <%s>: %s", raw, spec.Name, reason)).
+		WithUserMessage(fmt.Sprintf("Invalid value '%s' for argument '%s': %s", raw, spec.Name, reason)).
+		WithContext("argument", spec.Name).
+		WithContext("value", raw)
+}
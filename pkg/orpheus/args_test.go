@@ -0,0 +1,207 @@
+// args_test.go: tests for declarative positional arguments in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agilira/orpheus/pkg/orpheus"
+)
+
+func TestArgsRequiredAndOptional(t *testing.T) {
+	var source, dest string
+	var hasDest bool
+
+	app := orpheus.New("testapp")
+	cmd := orpheus.NewCommand("copy", "Copy files").
+		AddArg("source", "Source file").
+		AddOptionalArg("dest", "Destination file").
+		SetHandler(func(ctx *orpheus.Context) error {
+			source = ctx.Arg("source")
+			dest = ctx.Arg("dest")
+			hasDest = ctx.HasArg("dest")
+			return nil
+		})
+	app.AddCommand(cmd)
+
+	if err := app.Run([]string{"copy", "a.txt", "b.txt"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if source != "a.txt" || dest != "b.txt" || !hasDest {
+		t.Errorf("unexpected args: source=%q dest=%q hasDest=%v", source, dest, hasDest)
+	}
+
+	if err := app.Run([]string{"copy", "only.txt"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if source != "only.txt" || dest != "" || hasDest {
+		t.Errorf("unexpected args: source=%q dest=%q hasDest=%v", source, dest, hasDest)
+	}
+}
+
+func TestArgsMissingRequired(t *testing.T) {
+	executed := false
+	app := orpheus.New("testapp")
+	app.AddCommand(orpheus.NewCommand("copy", "Copy files").
+		AddArg("source", "Source file").
+		AddArg("dest", "Destination file").
+		SetHandler(func(ctx *orpheus.Context) error {
+			executed = true
+			return nil
+		}))
+
+	err := app.Run([]string{"copy", "a.txt"})
+	if err == nil {
+		t.Fatal("expected error for missing argument")
+	}
+	if executed {
+		t.Error("handler should not run when arguments are missing")
+	}
+
+	var orpheusErr *orpheus.Error
+	if !errors.As(err, &orpheusErr) || !orpheusErr.IsValidationError() {
+		t.Fatalf("expected validation error, got %T: %v", err, err)
+	}
+	if !strings.Contains(err.Error(), "<dest>") {
+		t.Errorf("error should name the missing argument, got: %v", err)
+	}
+}
+
+func TestArgsTooMany(t *testing.T) {
+	app := orpheus.New("testapp")
+	app.AddCommand(orpheus.NewCommand("show", "Show item").
+		AddArg("id", "Item ID").
+		SetHandler(func(ctx *orpheus.Context) error { return nil }))
+
+	err := app.Run([]string{"show", "1", "2"})
+	if err == nil || !strings.Contains(err.Error(), "too many arguments") {
+		t.Errorf("expected too many arguments error, got: %v", err)
+	}
+}
+
+func TestArgsVariadic(t *testing.T) {
+	var files []string
+	app := orpheus.New("testapp")
+	app.AddCommand(orpheus.NewCommand("rm", "Remove files").
+		AddBoolFlag("force", "f", false, "Force removal").
+		AddVariadicArg("files", "Files to remove", true).
+		SetHandler(func(ctx *orpheus.Context) error {
+			files = ctx.ArgStrings("files")
+			return nil
+		}))
+
+	if err := app.Run([]string{"rm", "--force", "a", "b", "c"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(files, ",") != "a,b,c" {
+		t.Errorf("expected [a b c], got %v", files)
+	}
+
+	if err := app.Run([]string{"rm"}); err == nil {
+		t.Error("expected error when required variadic argument is missing")
+	}
+}
+
+func TestArgsTypedValues(t *testing.T) {
+	var count int
+	var ratio float64
+	var enabled bool
+	var wait time.Duration
+
+	cmd := orpheus.NewCommand("tune", "Tune settings").
+		AddArgSpec(orpheus.ArgSpec{Name: "count", Type: orpheus.ArgInt, Required: true}).
+		AddArgSpec(orpheus.ArgSpec{Name: "ratio", Type: orpheus.ArgFloat64, Required: true}).
+		AddArgSpec(orpheus.ArgSpec{Name: "enabled", Type: orpheus.ArgBool, Required: true}).
+		AddArgSpec(orpheus.ArgSpec{Name: "wait", Type: orpheus.ArgDuration, Required: true}).
+		SetHandler(func(ctx *orpheus.Context) error {
+			count = ctx.ArgInt("count")
+			ratio = ctx.ArgFloat64("ratio")
+			enabled = ctx.ArgBool("enabled")
+			wait = ctx.ArgDuration("wait")
+			return nil
+		})
+
+	err := cmd.Execute(&orpheus.Context{Args: []string{"3", "0.5", "true", "2s"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 3 || ratio != 0.5 || !enabled || wait != 2*time.Second {
+		t.Errorf("unexpected typed values: %d %v %v %v", count, ratio, enabled, wait)
+	}
+
+	err = cmd.Execute(&orpheus.Context{Args: []string{"three", "0.5", "true", "2s"}})
+	if err == nil || !strings.Contains(err.Error(), "<count>") {
+		t.Errorf("expected conversion error naming the argument, got: %v", err)
+	}
+}
+
+func TestArgsValidator(t *testing.T) {
+	cmd := orpheus.NewCommand("deploy", "Deploy").
+		AddArgSpec(orpheus.ArgSpec{
+			Name:     "env",
+			Required: true,
+			Validator: func(value string) error {
+				if value != "dev" && value != "prod" {
+					return fmt.Errorf("must be dev or prod")
+				}
+				return nil
+			},
+		}).
+		SetHandler(func(ctx *orpheus.Context) error { return nil })
+
+	if err := cmd.Execute(&orpheus.Context{Args: []string{"dev"}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := cmd.Execute(&orpheus.Context{Args: []string{"qa"}})
+	if err == nil || !strings.Contains(err.Error(), "must be dev or prod") {
+		t.Errorf("expected validator error, got: %v", err)
+	}
+}
+
+func TestArgsInvalidDeclaration(t *testing.T) {
+	cmd := orpheus.NewCommand("bad", "Bad declaration").
+		AddVariadicArg("files", "Files", false).
+		AddArg("dest", "Destination").
+		SetHandler(func(ctx *orpheus.Context) error { return nil })
+
+	err := cmd.Execute(&orpheus.Context{Args: []string{"a", "b"}})
+	var orpheusErr *orpheus.Error
+	if !errors.As(err, &orpheusErr) || orpheusErr.ErrorCode() != orpheus.ErrCodeInternal {
+		t.Errorf("expected internal error for invalid declaration, got: %v", err)
+	}
+}
+
+func TestArgsUsageAndHelp(t *testing.T) {
+	app := orpheus.New("testapp")
+	cmd := orpheus.NewCommand("copy", "Copy files").
+		AddArg("source", "Source file").
+		AddVariadicArg("dest", "Destination files", false).
+		SetHandler(func(ctx *orpheus.Context) error { return nil })
+	app.AddCommand(cmd)
+
+	if cmd.Usage() != "copy [flags] <source> [dest...]" {
+		t.Errorf("unexpected usage: %q", cmd.Usage())
+	}
+
+	help := app.GetHelpGenerator().GenerateCommandHelp(cmd)
+	for _, expected := range []string{
+		"Usage: testapp copy [flags] <source> [dest...]",
+		"Arguments:",
+		"<source>",
+		"Source file",
+		"Destination files (optional)",
+	} {
+		if !strings.Contains(help, expected) {
+			t.Errorf("help should contain %q, got:\n%s", expected, help)
+		}
+	}
+}
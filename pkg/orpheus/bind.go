@@ -0,0 +1,358 @@
+// bind.go: struct-tag based flag and argument binding in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	flashflags "github.com/agilira/flash-flags"
+)
+
+var (
+	durationType  = reflect.TypeOf(time.Duration(0))
+	stringMapType = reflect.TypeOf(map[string]string(nil))
+)
+
+// optionField is a struct field bound to a flag or a positional argument.
+type optionField struct {
+	index     []int
+	field     reflect.StructField
+	flag      string // flag name, empty for arguments
+	shorthand string
+	arg       string // argument name, empty for flags
+}
+
+// BindOptions registers flags and positional arguments from the struct tags of opts,
+// which must be a pointer to a struct. Fields are bound with:
+//
+//	flag:"name,n"    flag name and optional shorthand
+//	arg:"name"       positional argument (a slice field makes it variadic)
+//	default:"value"  default value (otherwise the current field value is used)
+//	usage:"text"     description shown in help
+//	env:"VAR"        environment variable read when the flag is not given
+//	required:"true"  flag or argument must be provided
+//	prefix:"tls-"    on a nested struct, prepended to the flag names it declares
+//
+// Supported types are string, bool, int, int64, uint, float64, time.Duration,
+// []string, []int and map[string]string. Nested structs without a tag group
+// related flags. Use Context.Bind in the handler to fill the struct.
+func (c *Command) BindOptions(opts interface{}) *Command {
+	value, err := optionsValue(c.name, opts)
+	if err != nil {
+		c.bindErr = err
+		return c
+	}
+
+	fields, err := optionFields(c.name, value.Type())
+	if err != nil {
+		c.bindErr = err
+		return c
+	}
+
+	for _, f := range fields {
+		if err := c.bindOption(f, value.FieldByIndex(f.index)); err != nil {
+			c.bindErr = err
+			return c
+		}
+	}
+	return c
+}
+
+// Bind fills opts from the parsed flags and positional arguments of the command.
+// Fields are matched using the same struct tags as Command.BindOptions; flags
+// not defined by the command resolve like Context.GetFlag.
+func (ctx *Context) Bind(opts interface{}) error {
+	command := ""
+	if ctx.Command != nil {
+		command = ctx.Command.name
+	}
+
+	value, err := optionsValue(command, opts)
+	if err != nil {
+		return err
+	}
+
+	fields, err := optionFields(command, value.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, f := range fields {
+		target := value.FieldByIndex(f.index)
+		if f.arg != "" {
+			if err := bindArgValue(command, f, target, ctx.ArgValue(f.arg)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		fs := ctx.lookupFlagSet(f.flag)
+		if fs == nil || fs.Lookup(f.flag) == nil {
+			return InternalError(fmt.Sprintf("command '%s': field %s is bound to undefined flag --%s", command, f.field.Name, f.flag))
+		}
+		flagValue, ok := readFlagValue(fs, f.flag, target.Type())
+		if !ok {
+			return InternalError(fmt.Sprintf("command '%s': field %s has unsupported type %s", command, f.field.Name, target.Type()))
+		}
+		target.Set(flagValue)
+	}
+	return nil
+}
+
+// optionsValue checks that opts is a non-nil pointer to a struct and returns the struct.
+func optionsValue(command string, opts interface{}) (reflect.Value, error) {
+	value := reflect.ValueOf(opts)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, InternalError(fmt.Sprintf("command '%s': options must be a non-nil pointer to a struct, got %T", command, opts))
+	}
+	return value.Elem(), nil
+}
+
+// optionFields collects the tagged fields of t, descending into nested structs.
+func optionFields(command string, t reflect.Type) ([]optionField, error) {
+	var fields []optionField
+	err := collectOptionFields(command, t, nil, "", &fields)
+	return fields, err
+}
+
+// collectOptionFields walks t's fields, including prefix-nested structs, and collects flag and argument bindings.
+func collectOptionFields(command string, t reflect.Type, index []int, prefix string, fields *[]optionField) error {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		path := append(append([]int(nil), index...), i)
+
+		flagTag, hasFlag := sf.Tag.Lookup("flag")
+		argTag, hasArg := sf.Tag.Lookup("arg")
+
+		switch {
+		case flagTag == "-":
+			continue
+		case hasFlag && hasArg:
+			return InternalError(fmt.Sprintf("command '%s': field %s cannot be both a flag and an argument", command, sf.Name))
+		case hasFlag:
+			name, shorthand, _ := strings.Cut(flagTag, ",")
+			if name == "" {
+				return InternalError(fmt.Sprintf("command '%s': field %s has an empty flag name", command, sf.Name))
+			}
+			*fields = append(*fields, optionField{index: path, field: sf, flag: prefix + name, shorthand: shorthand})
+		case hasArg:
+			if argTag == "" {
+				return InternalError(fmt.Sprintf("command '%s': field %s has an empty argument name", command, sf.Name))
+			}
+			*fields = append(*fields, optionField{index: path, field: sf, arg: argTag})
+		case sf.Type.Kind() == reflect.Struct:
+			if err := collectOptionFields(command, sf.Type, path, prefix+sf.Tag.Get("prefix"), fields); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// bindOption registers the flag or argument declared by a single field.
+func (c *Command) bindOption(f optionField, current reflect.Value) error {
+	usage := f.field.Tag.Get("usage")
+	required := f.field.Tag.Get("required") == "true"
+
+	if f.arg != "" {
+		argType, variadic, ok := argTypeOf(f.field.Type)
+		if !ok {
+			return c.unsupportedOptionError(f)
+		}
+		c.AddArgSpec(ArgSpec{Name: f.arg, Description: usage, Type: argType, Required: required, Variadic: variadic})
+		return nil
+	}
+
+	if defaultTag, ok := f.field.Tag.Lookup("default"); ok {
+		parsed, err := parseOptionDefault(defaultTag, f.field.Type)
+		if err != nil {
+			return InternalError(fmt.Sprintf("command '%s': invalid default %q for flag --%s: %v", c.name, defaultTag, f.flag, err))
+		}
+		current = parsed
+	}
+
+	switch t := f.field.Type; {
+	case t == durationType:
+		c.AddDurationFlag(f.flag, f.shorthand, time.Duration(current.Int()), usage)
+	case t == stringMapType:
+		c.AddStringMapFlag(f.flag, f.shorthand, current.Interface().(map[string]string), usage)
+	case t.Kind() == reflect.String:
+		c.AddFlag(f.flag, f.shorthand, current.String(), usage)
+	case t.Kind() == reflect.Bool:
+		c.AddBoolFlag(f.flag, f.shorthand, current.Bool(), usage)
+	case t.Kind() == reflect.Int:
+		c.AddIntFlag(f.flag, f.shorthand, int(current.Int()), usage)
+	case t.Kind() == reflect.Int64:
+		c.AddInt64Flag(f.flag, f.shorthand, current.Int(), usage)
+	case t.Kind() == reflect.Uint:
+		c.AddUintFlag(f.flag, f.shorthand, uint(current.Uint()), usage)
+	case t.Kind() == reflect.Float64:
+		c.AddFloat64Flag(f.flag, f.shorthand, current.Float(), usage)
+	case t == reflect.TypeOf([]string(nil)):
+		c.AddStringSliceFlag(f.flag, f.shorthand, current.Interface().([]string), usage)
+	case t == reflect.TypeOf([]int(nil)):
+		c.AddIntSliceFlag(f.flag, f.shorthand, current.Interface().([]int), usage)
+	default:
+		return c.unsupportedOptionError(f)
+	}
+
+	if env := f.field.Tag.Get("env"); env != "" {
+		c.SetFlagEnv(f.flag, env)
+	}
+	if required {
+		c.MarkFlagRequired(f.flag)
+	}
+	return nil
+}
+
+// unsupportedOptionError reports a bound field whose type cannot be used.
+func (c *Command) unsupportedOptionError(f optionField) *Error {
+	return InternalError(fmt.Sprintf("command '%s': field %s has unsupported type %s", c.name, f.field.Name, f.field.Type))
+}
+
+// argTypeOf maps a field type to an argument type; slices make the argument variadic.
+func argTypeOf(t reflect.Type) (ArgType, bool, bool) {
+	variadic := false
+	if t.Kind() == reflect.Slice {
+		variadic = true
+		t = t.Elem()
+	}
+
+	switch {
+	case t == durationType:
+		return ArgDuration, variadic, true
+	case t.Kind() == reflect.String:
+		return ArgString, variadic, true
+	case t.Kind() == reflect.Int:
+		return ArgInt, variadic, true
+	case t.Kind() == reflect.Float64:
+		return ArgFloat64, variadic, true
+	case t.Kind() == reflect.Bool:
+		return ArgBool, variadic, true
+	default:
+		return ArgString, false, false
+	}
+}
+
+// parseOptionDefault converts a default tag into a value of type t.
+func parseOptionDefault(raw string, t reflect.Type) (reflect.Value, error) {
+	var items []string
+	if raw != "" {
+		items = strings.Split(raw, ",")
+	}
+
+	var parsed interface{}
+	var err error
+	switch {
+	case t == durationType:
+		parsed, err = time.ParseDuration(raw)
+	case t == stringMapType:
+		parsed, err = parseStringMap(items)
+	case t.Kind() == reflect.String:
+		parsed = raw
+	case t.Kind() == reflect.Bool:
+		parsed, err = strconv.ParseBool(raw)
+	case t.Kind() == reflect.Int:
+		parsed, err = strconv.Atoi(raw)
+	case t.Kind() == reflect.Int64:
+		parsed, err = parseInt64(raw)
+	case t.Kind() == reflect.Uint:
+		parsed, err = parseUint(raw)
+	case t.Kind() == reflect.Float64:
+		parsed, err = strconv.ParseFloat(raw, 64)
+	case t == reflect.TypeOf([]string(nil)):
+		parsed = items
+	case t == reflect.TypeOf([]int(nil)):
+		parsed, err = parseIntSlice(items)
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported type %s", t)
+	}
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(parsed).Convert(t), nil
+}
+
+// readFlagValue reads a parsed flag as a value of type t, reporting false for unsupported types.
+func readFlagValue(fs *flashflags.FlagSet, name string, t reflect.Type) (reflect.Value, bool) {
+	var value interface{}
+	switch {
+	case t == durationType:
+		value = fs.GetDuration(name)
+	case t == stringMapType:
+		value = flagStringMap(fs, name)
+	case t.Kind() == reflect.String:
+		value = fs.GetString(name)
+	case t.Kind() == reflect.Bool:
+		value = fs.GetBool(name)
+	case t.Kind() == reflect.Int:
+		value = fs.GetInt(name)
+	case t.Kind() == reflect.Int64:
+		value = flagInt64(fs, name)
+	case t.Kind() == reflect.Uint:
+		value = flagUint(fs, name)
+	case t.Kind() == reflect.Float64:
+		value = fs.GetFloat64(name)
+	case t == reflect.TypeOf([]string(nil)):
+		value = fs.GetStringSlice(name)
+	case t == reflect.TypeOf([]int(nil)):
+		value = flagIntSlice(fs, name)
+	default:
+		return reflect.Value{}, false
+	}
+	return reflect.ValueOf(value).Convert(t), true
+}
+
+// bindArgValue stores a converted positional argument into target.
+// Arguments that were not provided leave the field unchanged.
+func bindArgValue(command string, f optionField, target reflect.Value, value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	mismatch := InternalError(fmt.Sprintf("command '%s': argument <%s> cannot be stored in field %s of type %s", command, f.arg, f.field.Name, target.Type()))
+
+	items, variadic := value.([]interface{})
+	if !variadic {
+		converted, ok := convertArgValue(value, target.Type())
+		if !ok {
+			return mismatch
+		}
+		target.Set(converted)
+		return nil
+	}
+
+	if target.Kind() != reflect.Slice {
+		return mismatch
+	}
+	slice := reflect.MakeSlice(target.Type(), 0, len(items))
+	for _, item := range items {
+		converted, ok := convertArgValue(item, target.Type().Elem())
+		if !ok {
+			return mismatch
+		}
+		slice = reflect.Append(slice, converted)
+	}
+	target.Set(slice)
+	return nil
+}
+
+// convertArgValue converts an argument value to t when both have the same kind.
+func convertArgValue(value interface{}, t reflect.Type) (reflect.Value, bool) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != t.Kind() || !v.Type().ConvertibleTo(t) {
+		return reflect.Value{}, false
+	}
+	return v.Convert(t), true
+}
@@ -0,0 +1,196 @@
+// bind_test.go: tests for struct-tag based flag and argument binding in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agilira/orpheus/pkg/orpheus"
+)
+
+type tlsOptions struct {
+	Cert string `flag:"cert" usage:"TLS certificate"`
+	Key  string `flag:"key" usage:"TLS key"`
+}
+
+type deployOptions struct {
+	Env      string            `flag:"env,e" usage:"Target environment" required:"true"`
+	Replicas int               `flag:"replicas,r" default:"2" usage:"Replica count"`
+	Timeout  time.Duration     `flag:"timeout" default:"30s" usage:"Rollout timeout"`
+	DryRun   bool              `flag:"dry-run" usage:"Print actions only"`
+	Ratio    float64           `flag:"ratio" default:"0.5" usage:"Canary ratio"`
+	Offset   int64             `flag:"offset" usage:"Start offset"`
+	Workers  uint              `flag:"workers" default:"4" usage:"Workers"`
+	Regions  []string          `flag:"regions" default:"eu,us" usage:"Regions"`
+	Ports    []int             `flag:"ports" usage:"Ports"`
+	Labels   map[string]string `flag:"label" usage:"Labels"`
+	Token    string            `flag:"token" env:"DEPLOY_TOKEN" usage:"API token"`
+	TLS      tlsOptions        `prefix:"tls-"`
+	App      string            `arg:"app" usage:"Application name" required:"true"`
+	Files    []string          `arg:"files" usage:"Manifests"`
+	internal string
+	Ignored  string `flag:"-"`
+}
+
+func newBindApp(opts *deployOptions, handler orpheus.CommandHandler) (*orpheus.App, *orpheus.Command) {
+	app := orpheus.New("testapp")
+	cmd := orpheus.NewCommand("deploy", "Deploy application").
+		BindOptions(opts).
+		SetHandler(handler)
+	app.AddCommand(cmd)
+	return app, cmd
+}
+
+func TestBindOptions(t *testing.T) {
+	t.Setenv("DEPLOY_TOKEN", "s3cret")
+
+	var opts deployOptions
+	app, _ := newBindApp(&opts, func(ctx *orpheus.Context) error {
+		return ctx.Bind(&opts)
+	})
+
+	err := app.Run([]string{"deploy", "-e", "prod", "--replicas", "5", "--dry-run",
+		"--ports", "80,443", "--label", "team=core", "--tls-cert", "cert.pem", "web", "a.yaml", "b.yaml"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := deployOptions{
+		Env:      "prod",
+		Replicas: 5,
+		Timeout:  30 * time.Second,
+		DryRun:   true,
+		Ratio:    0.5,
+		Workers:  4,
+		Regions:  []string{"eu", "us"},
+		Ports:    []int{80, 443},
+		Labels:   map[string]string{"team": "core"},
+		Token:    "s3cret",
+		TLS:      tlsOptions{Cert: "cert.pem"},
+		App:      "web",
+		Files:    []string{"a.yaml", "b.yaml"},
+	}
+	if !reflect.DeepEqual(opts, want) {
+		t.Errorf("bound options:\n got %+v\nwant %+v", opts, want)
+	}
+}
+
+func TestBindOptionsRequired(t *testing.T) {
+	var opts deployOptions
+	app, _ := newBindApp(&opts, func(ctx *orpheus.Context) error { return ctx.Bind(&opts) })
+
+	err := app.Run([]string{"deploy", "web"})
+	requireValidationError(t, err, "required flag --env not provided")
+
+	err = app.Run([]string{"deploy", "--env", "prod"})
+	requireValidationError(t, err, "missing required argument <app>")
+}
+
+func TestBindOptionsHelp(t *testing.T) {
+	var opts deployOptions
+	app, cmd := newBindApp(&opts, func(*orpheus.Context) error { return nil })
+
+	help := app.GetHelpGenerator().GenerateCommandHelp(cmd)
+	for _, want := range []string{"--replicas INT", "Replica count (default: 2)", "--tls-key", "<app> [files...]"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("help missing %q:\n%s", want, help)
+		}
+	}
+}
+
+func TestBindOptionsUsesFieldValuesAsDefaults(t *testing.T) {
+	opts := struct {
+		Host string `flag:"host"`
+		Port int    `flag:"port"`
+	}{Host: "localhost", Port: 8080}
+
+	app := orpheus.New("testapp")
+	app.AddCommand(orpheus.NewCommand("serve", "Serve").
+		BindOptions(&opts).
+		SetHandler(func(ctx *orpheus.Context) error { return ctx.Bind(&opts) }))
+
+	if err := app.Run([]string{"serve", "--port", "9090"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Host != "localhost" || opts.Port != 9090 {
+		t.Errorf("got host=%q port=%d", opts.Host, opts.Port)
+	}
+}
+
+func TestBindGlobalFlags(t *testing.T) {
+	var opts struct {
+		Verbose bool   `flag:"verbose"`
+		Name    string `flag:"name"`
+	}
+
+	app := orpheus.New("testapp").AddGlobalBoolFlag("verbose", "", false, "Verbose")
+	app.AddCommand(orpheus.NewCommand("greet", "Greet").
+		AddFlag("name", "n", "world", "Name").
+		SetHandler(func(ctx *orpheus.Context) error { return ctx.Bind(&opts) }))
+
+	if err := app.Run([]string{"--verbose", "greet", "-n", "gopher"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !opts.Verbose || opts.Name != "gopher" {
+		t.Errorf("got %+v", opts)
+	}
+}
+
+func TestBindOptionsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts interface{}
+		want string
+	}{
+		{"not a pointer", struct{}{}, "must be a non-nil pointer to a struct"},
+		{"unsupported type", &struct {
+			C chan int `flag:"c"`
+		}{}, "unsupported type"},
+		{"invalid default", &struct {
+			N int `flag:"n" default:"many"`
+		}{}, `invalid default "many" for flag --n`},
+		{"flag and arg", &struct {
+			X string `flag:"x" arg:"x"`
+		}{}, "cannot be both a flag and an argument"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := orpheus.New("testapp")
+			app.AddCommand(orpheus.NewCommand("run", "Run").
+				BindOptions(tt.opts).
+				SetHandler(func(*orpheus.Context) error { return nil }))
+
+			err := app.Run([]string{"run"})
+			var orpheusErr *orpheus.Error
+			if !errors.As(err, &orpheusErr) || orpheusErr.ErrorCode() != orpheus.ErrCodeInternal {
+				t.Fatalf("expected internal error, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error to contain %q, got: %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestBindUndefinedFlag(t *testing.T) {
+	var opts struct {
+		Missing string `flag:"missing"`
+	}
+
+	app := orpheus.New("testapp")
+	app.Command("run", "Run", func(ctx *orpheus.Context) error { return ctx.Bind(&opts) })
+
+	err := app.Run([]string{"run"})
+	if err == nil || !strings.Contains(err.Error(), "undefined flag --missing") {
+		t.Errorf("expected undefined flag error, got %v", err)
+	}
+}
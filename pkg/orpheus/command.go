@@ -7,8 +7,10 @@
 package orpheus
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"strings"
+	"time"
 
 	flashflags "github.com/agilira/flash-flags"
 )
@@ -26,6 +28,8 @@ const (
 	CompletionFlags
 	// CompletionArgs suggests possible arguments for a command
 	CompletionArgs
+	// CompletionFlagValues suggests values for a flag
+	CompletionFlagValues
 )
 
 // CompletionRequest represents a request for tab completion.
@@ -34,11 +38,15 @@ type CompletionRequest struct {
 	Type CompletionType
 	// CurrentWord is the word currently being completed
 	CurrentWord string
-	// Command is the command being completed (if applicable)
+	// Command is the full name of the command being completed, e.g. "remote add"
 	Command string
-	// Args are the arguments provided so far
+	// Cmd is the resolved command being completed, nil for global flags before the command name
+	Cmd *Command
+	// Flag is the long name of the flag whose value is completed (CompletionFlagValues)
+	Flag string
+	// Args are the arguments after the command path, including the current word
 	Args []string
-	// Position is the position in the command line
+	// Position is the 1-based position of the current word in Args
 	Position int
 }
 
@@ -46,20 +54,30 @@ type CompletionRequest struct {
 type CompletionResult struct {
 	// Suggestions is the list of completion suggestions
 	Suggestions []string
+	// Descriptions maps suggestions to a short description shown by zsh and fish
+	Descriptions map[string]string
 	// Directive provides hints to the shell about how to handle completions
 	Directive CompletionDirective
 }
 
-// CompletionDirective provides instructions to the shell.
+// CompletionDirective provides instructions to the shell. Directives are bit
+// flags and can be combined, e.g. CompletionNoSpace | CompletionKeepOrder.
 type CompletionDirective int
 
 const (
 	// CompletionDefault indicates normal completion behavior
-	CompletionDefault CompletionDirective = iota
+	CompletionDefault CompletionDirective = 0
 	// CompletionNoSpace indicates no space should be added after completion
-	CompletionNoSpace
+	CompletionNoSpace CompletionDirective = 1 << (iota - 1)
 	// CompletionNoFiles indicates file completion should be disabled
 	CompletionNoFiles
+	// CompletionFilterFileExt completes file names with the extensions given as
+	// suggestions (e.g. "yaml", "json") and directories
+	CompletionFilterFileExt
+	// CompletionFilterDirs completes directory names only
+	CompletionFilterDirs
+	// CompletionKeepOrder keeps the order of the suggestions instead of sorting them
+	CompletionKeepOrder
 )
 
 // CompletionHandler is a function that provides custom completion for a command.
@@ -67,25 +85,46 @@ type CompletionHandler func(req *CompletionRequest) *CompletionResult
 
 // Command represents a CLI command with its configuration and behavior.
 type Command struct {
-	name              string
-	description       string
-	longDescription   string
-	usage             string
-	examples          []string
-	flags             *flashflags.FlagSet
-	handler           CommandHandler
-	completionHandler CompletionHandler
-	subcommands       map[string]*Command
-	parent            *Command
+	name               string
+	aliases            []string
+	description        string
+	longDescription    string
+	usage              string
+	examples           []string
+	args               []ArgSpec
+	flags              *flashflags.FlagSet
+	flagMeta           *flagMeta
+	persistent         *flashflags.FlagSet // created by the first AddPersistent*Flag call
+	persistentMeta     *flagMeta
+	handler            CommandHandler
+	completionHandler  CompletionHandler
+	subcommands        map[string]*Command
+	unknownSubcommand  func(name string) error // overrides the error for unknown subcommand names
+	parent             *Command
+	middleware         []Middleware
+	preRun             CommandHandler
+	postRun            CommandHandler
+	persistentPreRun   CommandHandler
+	persistentPostRun  CommandHandler
+	timeout            time.Duration
+	bindErr            error // error from BindOptions, reported when the command runs
+	hidden             bool
+	deprecated         bool
+	deprecationMessage string
+	replacement        string
+	group              string
+	flagGroups         []string
 }
 
 // NewCommand creates a new command with the specified name and description.
 func NewCommand(name, description string) *Command {
 	return &Command{
-		name:        name,
-		description: description,
-		flags:       flashflags.New(name),
-		subcommands: make(map[string]*Command),
+		name:           name,
+		description:    description,
+		flags:          flashflags.New(name),
+		flagMeta:       newFlagMeta(),
+		persistentMeta: newFlagMeta(),
+		subcommands:    make(map[string]*Command),
 	}
 }
 
@@ -104,6 +143,9 @@ func (c *Command) Usage() string {
 	if c.usage != "" {
 		return c.usage
 	}
+	if c.HasArgSpecs() {
+		return c.name + " [flags] " + c.argsUsage()
+	}
 	return c.name + " [flags]"
 }
 
@@ -157,23 +199,13 @@ func (c *Command) AddIntFlag(name, shorthand string, defaultValue int, descripti
 
 // AddFloat64Flag adds a float64 flag to the command.
 func (c *Command) AddFloat64Flag(name, shorthand string, defaultValue float64, description string) *Command {
-	if shorthand != "" {
-		// flash-flags doesn't have Float64Var with shorthand, use basic method
-		c.flags.Float64(name, defaultValue, description)
-	} else {
-		c.flags.Float64(name, defaultValue, description)
-	}
+	addFloat64Flag(c.flags, c.flagMeta, name, shorthand, defaultValue, description)
 	return c
 }
 
 // AddStringSliceFlag adds a string slice flag to the command.
 func (c *Command) AddStringSliceFlag(name, shorthand string, defaultValue []string, description string) *Command {
-	if shorthand != "" {
-		// flash-flags doesn't have StringSliceVar with shorthand, use basic method
-		c.flags.StringSlice(name, defaultValue, description)
-	} else {
-		c.flags.StringSlice(name, defaultValue, description)
-	}
+	addStringSliceFlag(c.flags, c.flagMeta, name, shorthand, defaultValue, description)
 	return c
 }
 
@@ -186,6 +218,22 @@ func (c *Command) Execute(ctx *Context) error {
 		return c.showHelp(ctx)
 	}
 
+	// Warn about deprecated commands and forward them to their replacement
+	if c.deprecated {
+		c.warnDeprecatedCommand(ctx)
+		if target := c.forwardTarget(ctx.App); target != nil {
+			return target.Execute(&Context{
+				App:           ctx.App,
+				Args:          argsToparse,
+				GlobalFlags:   ctx.GlobalFlags,
+				Command:       target,
+				storage:       ctx.storage,
+				globalSources: ctx.globalSources,
+				stdCtx:        ctx.stdCtx,
+			})
+		}
+	}
+
 	// Handle subcommands if they exist
 	subcommandExecuted, err := c.handleSubcommands(ctx, argsToparse)
 	if err != nil {
@@ -202,13 +250,46 @@ func (c *Command) Execute(ctx *Context) error {
 		return c.showHelp(ctx)
 	}
 
-	// Validate handler existence
-	if err := c.validateHandler(); err != nil {
-		return err
+	// Apply the command timeout to the run context
+	if c.timeout > 0 {
+		timeoutCtx, cancel := context.WithTimeout(ctx.Context(), c.timeout)
+		defer cancel()
+		ctx.stdCtx = timeoutCtx
 	}
 
-	// Parse and execute
-	return c.parseAndExecute(ctx, argsToparse)
+	// Validate, parse and execute with automatic instrumentation
+	return c.instrument(ctx, argsToparse, func() error {
+		if err := c.validateHandler(); err != nil {
+			return err
+		}
+		if c.bindErr != nil {
+			return c.bindErr
+		}
+		return c.timeoutError(ctx, c.parseAndExecute(ctx, argsToparse))
+	})
+}
+
+// SetTimeout limits how long the command may run. When the timeout expires the
+// context returned by Context.Context() is cancelled; a handler returning the
+// resulting context error fails with a retryable ExecutionError.
+func (c *Command) SetTimeout(timeout time.Duration) *Command {
+	c.timeout = timeout
+	return c
+}
+
+// Timeout returns the command timeout, or 0 if none is set.
+func (c *Command) Timeout() time.Duration {
+	return c.timeout
+}
+
+// timeoutError converts a deadline error caused by the command timeout into an ExecutionError.
+func (c *Command) timeoutError(ctx *Context, err error) error {
+	if err == nil || c.timeout <= 0 || !errors.Is(err, context.DeadlineExceeded) || ctx.Context().Err() == nil {
+		return err
+	}
+	return ExecutionError(c.name, fmt.Sprintf("command timed out after %s", c.timeout)).
+		WithContext("timeout", c.timeout.String()).
+		AsRetryable()
 }
 
 // prepareArgs removes the command name from args if present
@@ -235,27 +316,30 @@ func (c *Command) handleSubcommands(ctx *Context, args []string) (bool, error) {
 		return false, nil
 	}
 
-	potentialSubcmd := args[0]
-
-	// Don't treat flags as subcommands
-	if strings.HasPrefix(potentialSubcmd, "-") {
+	// Persistent and global flags may precede the subcommand name; other flags belong to this command
+	index := skipInheritedFlags(c.inheritedScopes(ctx.App), args)
+	if index < 0 {
 		return false, nil
 	}
 
-	if subcmd := c.GetSubcommand(potentialSubcmd); subcmd != nil {
-		// Execute subcommand with remaining args
-		newCtx := &Context{
-			App:         ctx.App,
-			Args:        args[1:], // Remove subcommand name
-			GlobalFlags: ctx.GlobalFlags,
-			Command:     subcmd,
-		}
-		err := subcmd.Execute(newCtx)
-		return true, err // Subcommand was executed
+	// Unknown or ambiguous subcommands are errors
+	subcmd, err := c.resolveSubcommand(ctx.App, args[index])
+	if err != nil {
+		return false, err
 	}
 
-	// Subcommand not found - this is an error
-	return false, NotFoundError(c.name+" "+potentialSubcmd, fmt.Sprintf("unknown subcommand '%s' for command '%s'", potentialSubcmd, c.name))
+	// Execute subcommand with the remaining args, keeping the inherited flags
+	subArgs := append(append([]string{}, args[:index]...), args[index+1:]...)
+	newCtx := &Context{
+		App:           ctx.App,
+		Args:          subArgs,
+		GlobalFlags:   ctx.GlobalFlags,
+		Command:       subcmd,
+		storage:       ctx.storage,
+		globalSources: ctx.globalSources,
+		stdCtx:        ctx.stdCtx,
+	}
+	return true, subcmd.Execute(newCtx) // Subcommand was executed
 }
 
 // validateHandler checks if the command has a valid handler
@@ -270,17 +354,63 @@ func (c *Command) validateHandler() error {
 
 // parseAndExecute handles flag parsing and handler execution
 func (c *Command) parseAndExecute(ctx *Context, args []string) error {
-	// Parse flags for this command
-	if err := c.flags.Parse(args); err != nil {
-		return ValidationError(c.name, "flag parsing failed: "+err.Error())
+	// Route persistent and global flags to their own sets, then parse them
+	scopes := c.inheritedScopes(ctx.App)
+	args = c.routeArgs(scopes, args)
+	inheritedSources, err := c.parseInheritedFlags(ctx, scopes)
+	if err != nil {
+		return err
+	}
+
+	// Parse flags for this command, starting from defaults on every run
+	c.flags.Reset()
+	if err := c.flags.Parse(c.flagMeta.expandShorthands(args)); err != nil {
+		return ctx.App.flagParseError(c.name, "flag parsing failed", c.flags, err)
+	}
+	positional := c.flags.Args()
+
+	// Fill flags not given on the command line from the environment
+	binding := &flagBinding{
+		app:     ctx.App,
+		command: c.name,
+		scope:   c.path(),
+		flags:   c.flags,
+		meta:    c.flagMeta,
+		sources: make(map[string]FlagSource),
+	}
+	binding.recordArgs()
+	if err := binding.applyEnvironment(); err != nil {
+		return err
+	}
+	if err := binding.applyConfig(); err != nil {
+		return err
+	}
+
+	// Enforce required flags and flag relationships
+	if err := c.flagMeta.validate(c.name, c.flags); err != nil {
+		return err
+	}
+	warnDeprecatedFlags(ctx, c.name, c.flags, c.flagMeta)
+
+	// Check arity and convert declared positional arguments
+	argValues, err := c.bindArgs(positional)
+	if err != nil {
+		return err
 	}
 
-	// Update context with parsed flags
+	// Update context with parsed flags and arguments
 	ctx.Flags = c.flags
 	ctx.Command = c
+	for name, source := range inheritedSources {
+		if _, local := binding.sources[name]; !local && c.flags.Lookup(name) == nil {
+			binding.sources[name] = source
+		}
+	}
+	ctx.flagSources = binding.sources
+	ctx.argValues = argValues
 
-	// Execute the handler
-	return c.handler(ctx)
+	// Execute the handler wrapped in middleware and lifecycle hooks
+	return c.executionChain(ctx.App)(ctx)
 }
 
 // Flags returns the command's flag set for advanced usage.
@@ -316,9 +446,17 @@ func (c *Command) HasSubcommands() bool {
 	return len(c.subcommands) > 0
 }
 
-// GetSubcommand returns a subcommand by name, or nil if not found.
+// GetSubcommand returns a subcommand by name or alias, or nil if not found.
 func (c *Command) GetSubcommand(name string) *Command {
-	return c.subcommands[name]
+	if subcmd, exists := c.subcommands[name]; exists {
+		return subcmd
+	}
+	for _, subcmd := range c.subcommands {
+		if subcmd.hasName(name) {
+			return subcmd
+		}
+	}
+	return nil
 }
 
 // Parent returns the parent command, or nil if this is a root command.
@@ -334,10 +472,18 @@ func (c *Command) FullName() string {
 	return c.parent.FullName() + " " + c.name
 }
 
+// path returns the command names from the root command down to this command.
+func (c *Command) path() []string {
+	if c.parent == nil {
+		return []string{c.name}
+	}
+	return append(c.parent.path(), c.name)
+}
+
 // showHelp displays help for the command.
 func (c *Command) showHelp(ctx *Context) error {
 	generator := NewHelpGenerator(ctx.App)
 	helpText := generator.GenerateCommandHelp(c)
-	fmt.Print(helpText)
+	fmt.Fprint(ctx.App.stdout(), helpText)
 	return nil
 }
@@ -8,50 +8,102 @@ package orpheus
 
 import (
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 
 	flashflags "github.com/agilira/flash-flags"
 )
 
-// GenerateCompletion generates bash completion script for the application.
+// completionShells lists the shells GenerateCompletionScript supports.
+var completionShells = []string{"bash", "zsh", "fish", "powershell", "nushell", "elvish"}
+
+// GenerateCompletion generates the completion script of the application for a
+// shell, falling back to bash for unsupported shells. Its signature has no error
+// result, so unlike GenerateCompletionScript and the completion command it keeps
+// this fallback for compatibility.
+//
+// Deprecated: Use GenerateCompletionScript, which reports unsupported shells.
 func (app *App) GenerateCompletion(shell string) string {
+	script, err := app.GenerateCompletionScript(shell)
+	if err != nil {
+		return app.generateBashCompletion()
+	}
+	return script
+}
+
+// GenerateCompletionScript generates the completion script of the application for
+// a shell: bash, zsh, fish, powershell, nushell or elvish. Other shell names are
+// rejected with a ValidationError.
+func (app *App) GenerateCompletionScript(shell string) (string, error) {
 	switch shell {
 	case "bash":
-		return app.generateBashCompletion()
+		return app.generateBashCompletion(), nil
 	case "zsh":
-		return app.generateZshCompletion()
+		return app.generateZshCompletion(), nil
 	case "fish":
-		return app.generateFishCompletion()
+		return app.generateFishCompletion(), nil
+	case "powershell":
+		return app.generatePowerShellCompletion(), nil
+	case "nushell":
+		return app.generateNushellCompletion(), nil
+	case "elvish":
+		return app.generateElvishCompletion(), nil
 	default:
-		return app.generateBashCompletion() // Default to bash
+		return "", unsupportedShellError(shell)
 	}
 }
 
-// Complete provides completion suggestions for the current input.
+// unsupportedShellError reports a shell without a completion script generator.
+func unsupportedShellError(shell string) error {
+	return ValidationError("completion", fmt.Sprintf("unsupported shell: %s (supported: %s)",
+		shell, strings.Join(completionShells, ", "))).
+		WithContext("shell", shell)
+}
+
+// Complete provides completion suggestions for the current input. args are the
+// words after the program name and position is the 1-based index of the word being
+// completed. Subcommands are resolved along the command path, so both subcommand
+// names and the flags of the active subcommand, including inherited persistent and
+// global flags, are suggested.
 func (app *App) Complete(args []string, position int) *CompletionResult {
 	if len(args) == 0 || position == 0 {
 		return app.completeCommands("")
 	}
 
-	// If we're completing the first argument, it's a command
-	if position == 1 {
-		return app.completeCommands(args[0])
+	// Global flags and their values may precede the command name, as in Run
+	before := min(position-1, len(args))
+	_, cmdArgs := app.splitGlobalArgs(args[:before])
+	if len(cmdArgs) == 0 {
+		return app.completeGlobalArgs(args, position)
 	}
+	skip := before - len(cmdArgs)
+	args, position = args[skip:], position-skip
 
 	// We're completing arguments or flags for a command
-	cmdName := args[0]
-	cmd, exists := app.commands[cmdName]
-	if !exists {
+	cmd, err := app.resolveCommand(args[0])
+	if err != nil || cmd == app.helpCommand {
 		return &CompletionResult{Suggestions: []string{}}
 	}
 
 	currentWord := ""
-	if position < len(args) {
-		currentWord = args[position]
-	} else if position == len(args) && len(args) > 1 {
-		// We're at the end, check the last argument
-		currentWord = args[len(args)-1]
+	preceding := args[1:]
+	if position <= len(args) {
+		currentWord = args[position-1]
+		preceding = args[1 : position-1]
+	}
+	cmd, rest, open := app.completionTarget(cmd, preceding)
+
+	// The words after the command path, from the current word on
+	argPosition := len(rest) + 1
+	words := rest
+	if position <= len(args) {
+		words = append(words, args[position-1:]...)
+	}
+
+	// Complete values of flags with a completion handler or a fixed set of choices
+	if result := app.completeFlagValue(cmd, words, argPosition); result != nil {
+		return result
 	}
 
 	// If current word starts with -, complete flags
@@ -59,13 +111,19 @@ func (app *App) Complete(args []string, position int) *CompletionResult {
 		return app.completeFlags(cmd, currentWord)
 	}
 
+	// A command with subcommands expects a subcommand name
+	if open {
+		return completeSubcommands(cmd, currentWord)
+	}
+
 	// Complete arguments for the command
 	req := &CompletionRequest{
 		Type:        CompletionArgs,
 		CurrentWord: currentWord,
-		Command:     cmdName,
-		Args:        args[1:],
-		Position:    position - 1,
+		Command:     cmd.FullName(),
+		Cmd:         cmd,
+		Args:        words,
+		Position:    argPosition,
 	}
 
 	// Use custom completion handler if available
@@ -77,51 +135,182 @@ func (app *App) Complete(args []string, position int) *CompletionResult {
 	return &CompletionResult{Suggestions: []string{}}
 }
 
+// completeGlobalArgs completes a word before the command name: the value of a
+// global flag, a global flag name or the command name itself.
+func (app *App) completeGlobalArgs(args []string, position int) *CompletionResult {
+	if result := app.completeFlagValue(nil, args, position); result != nil {
+		return result
+	}
+
+	current := ""
+	if position <= len(args) {
+		current = args[position-1]
+	}
+	if strings.HasPrefix(current, "-") {
+		return app.completeFlags(nil, current)
+	}
+	return app.completeCommands(current)
+}
+
+// completionTarget resolves the command being completed from the words preceding
+// the current one, descending into subcommands the way Execute does. It returns the
+// command, the words with the subcommand names removed, and whether the current word
+// is in subcommand position.
+func (app *App) completionTarget(cmd *Command, words []string) (*Command, []string, bool) {
+	words = append([]string{}, words...)
+	for cmd.HasSubcommands() {
+		scopes := cmd.inheritedScopes(app)
+		index := skipInheritedFlags(scopes, words)
+		if index < 0 {
+			// Only inherited flags so far: a subcommand name may follow them
+			probe := append(append([]string{}, words...), "")
+			return cmd, words, skipInheritedFlags(scopes, probe) == len(words)
+		}
+
+		subcmd, err := cmd.resolveSubcommand(app, words[index])
+		if err != nil {
+			return cmd, words, false
+		}
+		words = append(words[:index], words[index+1:]...)
+		cmd = subcmd
+	}
+	return cmd, words, false
+}
+
 // completeCommands provides completion for command names.
 func (app *App) completeCommands(partial string) *CompletionResult {
 	var suggestions []string
+	descriptions := make(map[string]string)
 
-	for name := range app.commands {
-		if strings.HasPrefix(name, partial) {
-			suggestions = append(suggestions, name)
+	for _, cmd := range visibleCommands(app.commands) {
+		for _, name := range cmd.names() {
+			if strings.HasPrefix(name, partial) {
+				suggestions = append(suggestions, name)
+				descriptions[name] = commandSummary(cmd)
+			}
 		}
 	}
 
 	// Add built-in commands
 	if strings.HasPrefix("help", partial) {
 		suggestions = append(suggestions, "help")
+		descriptions["help"] = "Show help for commands"
 	}
 
 	sort.Strings(suggestions)
-	return &CompletionResult{Suggestions: suggestions}
+	return &CompletionResult{Suggestions: suggestions, Descriptions: descriptions}
 }
 
-// completeFlags provides completion for command flags.
+// completeSubcommands provides completion for the subcommand names of a command.
+func completeSubcommands(cmd *Command, partial string) *CompletionResult {
+	suggestions := []string{}
+	descriptions := make(map[string]string)
+	for _, subcmd := range visibleCommands(cmd.subcommands) {
+		for _, name := range subcmd.names() {
+			if strings.HasPrefix(name, partial) {
+				suggestions = append(suggestions, name)
+				descriptions[name] = commandSummary(subcmd)
+			}
+		}
+	}
+
+	sort.Strings(suggestions)
+	return &CompletionResult{Suggestions: suggestions, Descriptions: descriptions, Directive: CompletionNoFiles}
+}
+
+// completeFlagValue completes the value of a flag, either as the word after the flag
+// (--format js) or inline (--format=js), with the handler set by SetFlagCompletion
+// or the choices of an enum flag. words are the words after the command path, or
+// all words when cmd is nil, and position is the 1-based index of the current word
+// in them. It returns nil when the word being completed is not a flag value.
+func (app *App) completeFlagValue(cmd *Command, words []string, position int) *CompletionResult {
+	current := ""
+	if position <= len(words) {
+		current = words[position-1]
+	}
+
+	prefix := ""
+	flagArg := ""
+	if name, value, found := strings.Cut(current, "="); found && strings.HasPrefix(name, "-") {
+		flagArg, prefix, current = name, name+"=", value
+	} else if position >= 2 && position-2 < len(words) && !strings.HasPrefix(current, "-") {
+		flagArg = words[position-2]
+	}
+
+	name, hasValue, ok := flagTokenName(flagArg)
+	if !ok || hasValue {
+		return nil
+	}
+	scope, flag := findScope(completionScopes(app, cmd), name)
+	if flag == nil || (prefix == "" && flag.Type() == "bool") {
+		return nil
+	}
+
+	opts := scope.meta.lookup(flag.Name())
+	if opts != nil && opts.completion != nil {
+		req := &CompletionRequest{
+			Type:        CompletionFlagValues,
+			CurrentWord: current,
+			Cmd:         cmd,
+			Flag:        flag.Name(),
+			Args:        words,
+			Position:    position,
+		}
+		if cmd != nil {
+			req.Command = cmd.FullName()
+		}
+		return prefixCompletions(prefix, opts.completion(req))
+	}
+
+	if opts == nil || opts.choices == nil {
+		// A value without completion: let the shell complete files
+		return &CompletionResult{Suggestions: []string{}}
+	}
+
+	suggestions := []string{}
+	for _, choice := range opts.choices {
+		if strings.HasPrefix(choice, current) {
+			suggestions = append(suggestions, prefix+choice)
+		}
+	}
+	return &CompletionResult{Suggestions: suggestions, Directive: CompletionNoFiles}
+}
+
+// completionScopes returns the flag sets a command accepts: its own flags, then
+// its inherited persistent flags and the global flags. Before the command name
+// (cmd is nil) only the global flags are accepted.
+func completionScopes(app *App, cmd *Command) []*flagScope {
+	if cmd == nil {
+		return []*flagScope{{flags: app.globalFlags, meta: app.globalFlagMeta}}
+	}
+	own := &flagScope{owner: cmd, flags: cmd.flags, meta: cmd.flagMeta}
+	return append([]*flagScope{own}, cmd.inheritedScopes(app)...)
+}
+
+// completeFlags provides completion for the flags of a command, including its
+// inherited persistent flags and the global flags.
 func (app *App) completeFlags(cmd *Command, partial string) *CompletionResult {
 	var suggestions []string
+	descriptions := map[string]string{"--help": "Show help", "-h": "Show help"}
 
-	// Add global flags
+	// Add built-in flags
 	suggestions = append(suggestions, "--help", "-h")
 	if app.version != "" {
 		suggestions = append(suggestions, "--version", "-v")
+		descriptions["--version"] = "Show version"
+		descriptions["-v"] = "Show version"
 	}
 
-	// Add custom global flags
-	if app.globalFlags != nil {
-		app.globalFlags.VisitAll(func(flag *flashflags.Flag) {
-			flagName := "--" + flag.Name()
-			if strings.HasPrefix(flagName, partial) {
-				suggestions = append(suggestions, flagName)
-			}
-		})
-	}
-
-	// Add command-specific flags
-	if cmd.Flags() != nil {
-		cmd.Flags().VisitAll(func(flag *flashflags.Flag) {
-			flagName := "--" + flag.Name()
-			if strings.HasPrefix(flagName, partial) {
-				suggestions = append(suggestions, flagName)
+	// Add command, persistent and custom global flags
+	for _, scope := range completionScopes(app, cmd) {
+		if scope.flags == nil {
+			continue
+		}
+		scope.flags.VisitAll(func(flag *flashflags.Flag) {
+			name := "--" + flag.Name()
+			if !isHiddenFlag(scope.meta.lookup(flag.Name())) && !seenFlag(suggestions, name) {
+				suggestions = append(suggestions, name)
+				descriptions[name] = flag.Usage()
 			}
 		})
 	}
@@ -138,181 +327,269 @@ func (app *App) completeFlags(cmd *Command, partial string) *CompletionResult {
 
 	sort.Strings(filtered)
 	return &CompletionResult{
-		Suggestions: filtered,
-		Directive:   CompletionNoFiles,
+		Suggestions:  filtered,
+		Descriptions: descriptions,
+		Directive:    CompletionNoFiles,
 	}
 }
 
-// generateBashCompletion generates a bash completion script.
-func (app *App) generateBashCompletion() string {
+// seenFlag reports whether name is already among the suggestions; a flag defined in
+// several scopes is described by the first, which takes precedence.
+func seenFlag(suggestions []string, name string) bool {
+	for _, suggestion := range suggestions {
+		if suggestion == name {
+			return true
+		}
+	}
+	return false
+}
+
+// completeCommandName is the hidden built-in command the generated completion
+// scripts call to obtain suggestions from the running binary.
+const completeCommandName = "__complete"
+
+// runComplete handles "__complete <words...>": it completes the last word, which is
+// empty when a new word is started, and prints one suggestion per line, followed by
+// a tab and its description when it has one, then a ":<directive>" line.
+func (app *App) runComplete(words []string) error {
+	result := app.Complete(words, len(words))
+
 	var sb strings.Builder
+	for _, suggestion := range result.Suggestions {
+		sb.WriteString(suggestion)
+		if description := result.Descriptions[suggestion]; description != "" {
+			sb.WriteString("\t" + strings.Join(strings.Fields(description), " "))
+		}
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, ":%d\n", result.Directive)
 
-	sb.WriteString(fmt.Sprintf(`# Bash completion for %s
-_%s_completion() {
-    local cur prev words cword
-    _init_completion || return
-
-    case $cword in
-        1)
-            # Complete command names
-            COMPREPLY=($(compgen -W "%s help" -- "$cur"))
-            return 0
-            ;;
-        *)
-            # Complete based on the command
-            case ${words[1]} in
-`, app.name, app.name, app.getCommandNames()))
-
-	// Add completion for each command
-	for name := range app.commands {
-		sb.WriteString(fmt.Sprintf(`                %s)
-                    COMPREPLY=($(compgen -W "--help -h" -- "$cur"))
-                    return 0
-                    ;;
-`, name))
-	}
-
-	sb.WriteString(`                help)
-                    COMPREPLY=($(compgen -W "`)
-	sb.WriteString(app.getCommandNames())
-	sb.WriteString(`" -- "$cur"))
-                    return 0
-                    ;;
-            esac
-            ;;
-    esac
+	_, err := io.WriteString(app.stdout(), sb.String())
+	return err
 }
 
-complete -F _`)
-	sb.WriteString(app.name)
-	sb.WriteString("_completion ")
-	sb.WriteString(app.name)
-	sb.WriteString("\n")
+// generateBashCompletion generates a bash completion script. The scripts are
+// fmt format strings, so a literal % in the shell code is written as %%.
+func (app *App) generateBashCompletion() string {
+	return fmt.Sprintf(`# Bash completion for %[1]s
+_%[1]s_completion() {
+    local cur words cword
+    if declare -F _get_comp_words_by_ref >/dev/null 2>&1; then
+        _get_comp_words_by_ref -n =: cur words cword
+    else
+        cur=${COMP_WORDS[COMP_CWORD]}
+        words=("${COMP_WORDS[@]}")
+        cword=$COMP_CWORD
+    fi
+
+    local out
+    out=$("${words[0]}" %[2]s "${words[@]:1:cword}" 2>/dev/null) || return
+    # The last line is ":<directive>"; suggestions may contain colons
+    local last=${out##*$'\n'}
+    local directive=${last#:}
+    out=${out%%"$last"}
+
+    # Descriptions are not shown by bash
+    local -a completions=()
+    local line
+    while IFS= read -r line; do
+        [[ -n $line ]] && completions+=("${line%%%%$'\t'*}")
+    done <<< "$out"
+
+    COMPREPLY=()
+    if (( directive & %[5]d )); then
+        # The suggestions are the file extensions to complete
+        compopt -o filenames 2>/dev/null
+        local file ext
+        while IFS= read -r file; do
+            for ext in "${completions[@]}"; do
+                if [[ -d $file || $file == *."$ext" ]]; then
+                    COMPREPLY+=("$file")
+                    break
+                fi
+            done
+        done < <(compgen -f -- "$cur")
+        return
+    fi
+    if (( directive & %[6]d )); then
+        compopt -o filenames 2>/dev/null
+        local IFS=$'\n'
+        COMPREPLY=($(compgen -d -- "$cur"))
+        return
+    fi
+
+    COMPREPLY=("${completions[@]}")
+
+    # Bash replaces only the text after "=" or ":" in the current word
+    if [[ $cur == *[=:]* ]]; then
+        local prefix=${cur%%"${cur##*[=:]}"}
+        COMPREPLY=("${COMPREPLY[@]#"$prefix"}")
+    fi
+
+    if (( directive & %[3]d )); then
+        compopt -o nospace 2>/dev/null
+    fi
+    if (( directive & %[7]d )); then
+        compopt -o nosort 2>/dev/null
+    fi
+    if (( ${#COMPREPLY[@]} == 0 && (directive & %[4]d) == 0 )); then
+        compopt -o default 2>/dev/null
+    fi
+}
 
-	return sb.String()
+complete -F _%[1]s_completion %[1]s
+`, app.name, completeCommandName, CompletionNoSpace, CompletionNoFiles,
+		CompletionFilterFileExt, CompletionFilterDirs, CompletionKeepOrder)
 }
 
 // generateZshCompletion generates a zsh completion script.
 func (app *App) generateZshCompletion() string {
-	var sb strings.Builder
-
-	sb.WriteString(fmt.Sprintf(`#compdef %s
-
-_%s() {
-    local context curcontext="$curcontext" state line
-    typeset -A opt_args
-
-    _arguments \
-        '1: :->commands' \
-        '*: :->args'
-
-    case $state in
-        commands)
-            _describe 'commands' '(
-`, app.name, app.name))
-
-	// Add command descriptions for zsh
-	for name, cmd := range app.commands {
-		sb.WriteString(fmt.Sprintf("                %s:'%s'\n", name, cmd.Description()))
-	}
-	sb.WriteString("                help:'Show help for commands'\n")
-
-	sb.WriteString(`            )'
-            ;;
-        args)
-            case $words[2] in
-                help)
-                    _describe 'commands' '(
-`)
-
-	for name, cmd := range app.commands {
-		sb.WriteString(fmt.Sprintf("                        %s:'%s'\n", name, cmd.Description()))
-	}
-
-	sb.WriteString(`                    )'
-                    ;;
-                *)
-                    _arguments \
-                        '--help[Show help]' \
-                        '-h[Show help]'
-                    ;;
-            esac
-            ;;
-    esac
+	return fmt.Sprintf(`#compdef %[1]s
+
+_%[1]s() {
+    local out
+    out=$("${words[1]}" %[2]s "${(@)words[2,CURRENT]}" 2>/dev/null) || return
+    # The last line is ":<directive>"; suggestions may contain colons
+    local last=${out##*$'\n'}
+    local directive=${last#:}
+    local -a lines
+    lines=("${(@f)${out%%"$last"}}")
+    lines=(${lines:#})
+
+    if (( directive & %[5]d )); then
+        # The suggestions are the file extensions to complete
+        local -a exts
+        exts=(${lines%%%%$'\t'*})
+        _files -g "*.(${(j:|:)exts})"
+        return
+    fi
+    if (( directive & %[6]d )); then
+        _files -/
+        return
+    fi
+    if (( ${#lines} == 0 )); then
+        (( directive & %[4]d )) || _files
+        return
+    fi
+
+    # "value<TAB>description" becomes "value:description" for _describe
+    local -a completions
+    local line value
+    for line in "${lines[@]}"; do
+        value=${line%%%%$'\t'*}
+        value=${value//:/\\:}
+        if [[ $line == *$'\t'* ]]; then
+            completions+=("$value:${line#*$'\t'}")
+        else
+            completions+=("$value")
+        fi
+    done
+
+    local -a opts
+    (( directive & %[3]d )) && opts+=(-S '')
+    if (( directive & %[7]d )); then
+        _describe -V 'completions' completions "${opts[@]}"
+    else
+        _describe 'completions' completions "${opts[@]}"
+    fi
 }
 
-_`)
-	sb.WriteString(app.name)
-	sb.WriteString(" \"$@\"\n")
-
-	return sb.String()
+if [[ "$funcstack[1]" == "_%[1]s" ]]; then
+    _%[1]s "$@"
+else
+    compdef _%[1]s %[1]s
+fi
+`, app.name, completeCommandName, CompletionNoSpace, CompletionNoFiles,
+		CompletionFilterFileExt, CompletionFilterDirs, CompletionKeepOrder)
 }
 
 // generateFishCompletion generates a fish completion script.
 func (app *App) generateFishCompletion() string {
-	var sb strings.Builder
-
-	sb.WriteString(fmt.Sprintf("# Fish completion for %s\n\n", app.name))
-
-	// Complete command names
-	sb.WriteString(fmt.Sprintf("complete -c %s -f\n", app.name))
-
-	// Add completions for each command
-	for name, cmd := range app.commands {
-		sb.WriteString(fmt.Sprintf("complete -c %s -n '__fish_use_subcommand' -a %s -d '%s'\n",
-			app.name, name, cmd.Description()))
-	}
-
-	// Add help command
-	sb.WriteString(fmt.Sprintf("complete -c %s -n '__fish_use_subcommand' -a help -d 'Show help for commands'\n",
-		app.name))
+	return fmt.Sprintf(`# Fish completion for %[1]s
+
+function __%[1]s_complete
+    set -l args (commandline -opc)
+    set -e args[1]
+    set -l current (commandline -ct)
+    set -l out (command %[1]s %[2]s $args "$current" 2>/dev/null)
+    or return
+    set -l directive (string replace ':' '' -- $out[-1])
+    set -e out[-1]
+
+    if test (math "bitand($directive, %[4]d)") -ne 0
+        # The suggestions are the file extensions to complete
+        for ext in (string replace -r '\t.*' '' -- $out)
+            __fish_complete_suffix ".$ext"
+        end
+        return
+    end
+    if test (math "bitand($directive, %[5]d)") -ne 0
+        __fish_complete_directories "$current"
+        return
+    end
+    if test (count $out) -eq 0; and test (math "bitand($directive, %[3]d)") -eq 0
+        __fish_complete_path "$current"
+        return
+    end
+
+    # Completions are registered with --keep-order; sort unless asked not to
+    if test (math "bitand($directive, %[6]d)") -ne 0
+        printf '%%s\n' $out
+    else
+        printf '%%s\n' $out | sort
+    end
+end
+
+complete -c %[1]s -f -k -a '(__%[1]s_complete)'
+`, app.name, completeCommandName, CompletionNoFiles,
+		CompletionFilterFileExt, CompletionFilterDirs, CompletionKeepOrder)
+}
 
-	// Add global flags
-	sb.WriteString(fmt.Sprintf("complete -c %s -s h -l help -d 'Show help'\n", app.name))
-	if app.version != "" {
-		sb.WriteString(fmt.Sprintf("complete -c %s -s v -l version -d 'Show version'\n", app.name))
-	}
+// AddCompletionCommand adds a built-in completion command to the app:
+// "completion [shell]" prints the completion script (bash by default), and
+// "completion install" and "completion uninstall" manage the per-user script.
+func (app *App) AddCompletionCommand() *App {
+	cmd := NewCommand("completion", "Generate shell completion scripts").
+		SetHandler(func(ctx *Context) error {
+			return app.printCompletion("bash")
+		})
 
-	// Add help completions for each command
-	for name := range app.commands {
-		sb.WriteString(fmt.Sprintf("complete -c %s -n '__fish_seen_subcommand_from help' -a %s\n",
-			app.name, name))
+	for _, shell := range completionShells {
+		cmd.AddSubcommand(NewCommand(shell, fmt.Sprintf("Generate the %s completion script", shell)).
+			SetHandler(func(ctx *Context) error {
+				return app.printCompletion(shell)
+			}))
 	}
-
-	return sb.String()
+	// Any other name is an unsupported shell rather than an unknown subcommand
+	cmd.unknownSubcommand = unsupportedShellError
+
+	cmd.AddSubcommand(NewCommand("install", "Install the completion script for your shell").
+		AddFlag("shell", "s", "", "Shell to install for (detected from $SHELL by default)").
+		AddBoolFlag("dry-run", "n", false, "Show what would change without writing").
+		SetFlagCompletion("shell", CompleteValues(installableShells...)).
+		SetHandler(func(ctx *Context) error {
+			return app.installCompletion(ctx.GetFlagString("shell"), ctx.GetFlagBool("dry-run"))
+		}))
+
+	cmd.AddSubcommand(NewCommand("uninstall", "Remove the installed completion script").
+		AddFlag("shell", "s", "", "Shell to uninstall for (detected from $SHELL by default)").
+		AddBoolFlag("dry-run", "n", false, "Show what would change without removing").
+		SetFlagCompletion("shell", CompleteValues(installableShells...)).
+		SetHandler(func(ctx *Context) error {
+			return app.uninstallCompletion(ctx.GetFlagString("shell"), ctx.GetFlagBool("dry-run"))
+		}))
+
+	app.AddCommand(cmd)
+	return app
 }
 
-// getCommandNames returns a space-separated list of command names.
-func (app *App) getCommandNames() string {
-	var names []string
-	for name := range app.commands {
-		names = append(names, name)
+// printCompletion writes the completion script of a shell to the output.
+func (app *App) printCompletion(shell string) error {
+	script, err := app.GenerateCompletionScript(shell)
+	if err != nil {
+		return err
 	}
-	sort.Strings(names)
-	return strings.Join(names, " ")
-}
-
-// AddCompletionCommand adds a built-in completion command to the app.
-func (app *App) AddCompletionCommand() *App {
-	app.Command("completion", "Generate shell completion scripts", func(ctx *Context) error {
-		shell := "bash" // default
-		if ctx.ArgCount() > 0 {
-			shell = ctx.GetArg(0)
-		}
-
-		validShells := map[string]bool{
-			"bash": true,
-			"zsh":  true,
-			"fish": true,
-		}
-
-		if !validShells[shell] {
-			return ValidationError("completion", fmt.Sprintf("unsupported shell: %s (supported: bash, zsh, fish)", shell))
-		}
 
-		fmt.Print(app.GenerateCompletion(shell))
-		return nil
-	})
-
-	return app
+	fmt.Fprint(app.stdout(), script)
+	return nil
 }
@@ -0,0 +1,277 @@
+// completion_install.go: per-user installation of completion scripts in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// installableShells lists the shells whose completion scripts are loaded from a
+// per-user directory and can therefore be installed automatically.
+var installableShells = []string{"bash", "zsh", "fish"}
+
+// installCompletion writes the completion script of a shell to its per-user
+// location; for zsh it also adds that directory to fpath in ~/.zshrc. Up-to-date
+// files are left untouched; with dryRun nothing is written.
+func (app *App) installCompletion(shell string, dryRun bool) error {
+	shell, path, err := app.completionInstallPath(shell)
+	if err != nil {
+		return err
+	}
+	script, err := app.GenerateCompletionScript(shell)
+	if err != nil {
+		return err
+	}
+
+	current, action, verb := false, "Installed", "install"
+	existing, err := os.ReadFile(path)
+	switch {
+	case err == nil && bytes.Equal(existing, []byte(script)):
+		current = true
+	case err == nil:
+		action, verb = "Updated", "update"
+	case !errors.Is(err, fs.ErrNotExist):
+		return ExecutionError("completion", fmt.Sprintf("cannot read %s: %v", path, err)).
+			WithContext("file", path)
+	}
+
+	// zsh only loads completion functions from the directories in fpath
+	var rc, line string
+	if shell == "zsh" {
+		var lines []string
+		if rc, line, lines, err = app.zshrcLine(filepath.Dir(path)); err != nil {
+			return err
+		}
+		if slices.Contains(lines, line) {
+			rc = ""
+		}
+	}
+
+	if current && rc == "" {
+		fmt.Fprintf(app.stdout(), "%s completion is up to date in %s\n", shell, path)
+		return nil
+	}
+	if dryRun {
+		if !current {
+			fmt.Fprintf(app.stdout(), "Would %s %s completion in %s\n", verb, shell, path)
+		}
+		if rc != "" {
+			fmt.Fprintf(app.stdout(), "Would add %s to fpath in %s\n", filepath.Dir(path), rc)
+		}
+		return nil
+	}
+
+	if !current {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return ExecutionError("completion", fmt.Sprintf("cannot create directory %s: %v", filepath.Dir(path), err)).
+				WithContext("dir", filepath.Dir(path))
+		}
+		if err := os.WriteFile(path, []byte(script), 0o644); err != nil {
+			return ExecutionError("completion", fmt.Sprintf("cannot write %s: %v", path, err)).
+				WithContext("file", path)
+		}
+		fmt.Fprintf(app.stdout(), "%s %s completion in %s\n", action, shell, path)
+	}
+	if rc != "" {
+		if err := editRCLine(rc, line, true); err != nil {
+			return err
+		}
+		fmt.Fprintf(app.stdout(), "Added %s to fpath in %s\n", filepath.Dir(path), rc)
+	}
+	return nil
+}
+
+// uninstallCompletion removes the completion script of a shell from its per-user
+// location and, for zsh, the fpath line added to ~/.zshrc. A missing script is not
+// an error; with dryRun nothing is removed.
+func (app *App) uninstallCompletion(shell string, dryRun bool) error {
+	shell, path, err := app.completionInstallPath(shell)
+	if err != nil {
+		return err
+	}
+
+	var rc, line string
+	if shell == "zsh" {
+		var lines []string
+		if rc, line, lines, err = app.zshrcLine(filepath.Dir(path)); err != nil {
+			return err
+		}
+		if !slices.Contains(lines, line) {
+			rc = ""
+		}
+	}
+
+	_, err = os.Stat(path)
+	installed := !errors.Is(err, fs.ErrNotExist)
+	if !installed && rc == "" {
+		fmt.Fprintf(app.stdout(), "%s completion is not installed in %s\n", shell, path)
+		return nil
+	}
+
+	if dryRun {
+		if installed {
+			fmt.Fprintf(app.stdout(), "Would remove %s completion from %s\n", shell, path)
+		}
+		if rc != "" {
+			fmt.Fprintf(app.stdout(), "Would remove %s from fpath in %s\n", filepath.Dir(path), rc)
+		}
+		return nil
+	}
+
+	if installed {
+		if err := os.Remove(path); err != nil {
+			return ExecutionError("completion", fmt.Sprintf("cannot remove %s: %v", path, err)).
+				WithContext("file", path)
+		}
+		fmt.Fprintf(app.stdout(), "Removed %s completion from %s\n", shell, path)
+	}
+	if rc != "" {
+		if err := editRCLine(rc, line, false); err != nil {
+			return err
+		}
+		fmt.Fprintf(app.stdout(), "Removed %s from fpath in %s\n", filepath.Dir(path), rc)
+	}
+	return nil
+}
+
+// zshrcLine returns the validated path of ~/.zshrc ($ZDOTDIR/.zshrc when set), the
+// line adding the zsh completion directory to fpath and the current lines of the
+// file. When compinit has already run, the line also registers the completion.
+func (app *App) zshrcLine(dir string) (string, string, []string, error) {
+	rc := filepath.Join(xdgDir("ZDOTDIR"), ".zshrc")
+	if !filepath.IsAbs(rc) {
+		return "", "", nil, ExecutionError("completion", "cannot determine the home directory for ~/.zshrc")
+	}
+	result := ValidateSecurePath(rc, DefaultSecurityConfig())
+	if !result.IsValid {
+		return "", "", nil, ValidationError("completion", fmt.Sprintf("zshrc path %s rejected: %s", rc, strings.Join(result.Errors, "; "))).
+			WithContext("file", rc)
+	}
+	rc = result.NormalizedPath
+
+	data, err := os.ReadFile(rc)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", "", nil, ExecutionError("completion", fmt.Sprintf("cannot read %s: %v", rc, err)).
+			WithContext("file", rc)
+	}
+	line := fmt.Sprintf("fpath=('%s' $fpath); (( $+functions[compdef] )) && autoload -Uz _%s && compdef _%s %s",
+		dir, app.name, app.name, app.name)
+	return rc, line, strings.Split(string(data), "\n"), nil
+}
+
+// editRCLine appends a line to a shell configuration file, creating it if needed,
+// or removes every occurrence of the line when add is false.
+func editRCLine(rc, line string, add bool) error {
+	data, err := os.ReadFile(rc)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return ExecutionError("completion", fmt.Sprintf("cannot read %s: %v", rc, err)).
+			WithContext("file", rc)
+	}
+
+	content := string(data)
+	if add {
+		if content != "" && !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		content += line + "\n"
+	} else {
+		lines := strings.Split(content, "\n")
+		content = strings.Join(slices.DeleteFunc(lines, func(l string) bool { return l == line }), "\n")
+	}
+
+	if err := os.WriteFile(rc, []byte(content), 0o644); err != nil {
+		return ExecutionError("completion", fmt.Sprintf("cannot write %s: %v", rc, err)).
+			WithContext("file", rc)
+	}
+	return nil
+}
+
+// completionInstallPath resolves the shell (detected from $SHELL when empty) and
+// the validated path of its per-user completion script:
+//
+//	bash: $BASH_COMPLETION_USER_DIR/completions/<app>, or $XDG_DATA_HOME/bash-completion/completions/<app>
+//	zsh:  $XDG_DATA_HOME/zsh/site-functions/_<app>, added to fpath in ~/.zshrc
+//	fish: $XDG_CONFIG_HOME/fish/completions/<app>.fish
+//
+// XDG_DATA_HOME and XDG_CONFIG_HOME default to ~/.local/share and ~/.config.
+func (app *App) completionInstallPath(shell string) (string, string, error) {
+	if shell == "" {
+		shell = detectShell()
+		if shell == "" {
+			return "", "", ValidationError("completion", "cannot detect the shell from $SHELL; use --shell").
+				WithUserMessage("Specify the shell with --shell (bash, zsh or fish)")
+		}
+	}
+
+	var dir, file string
+	switch shell {
+	case "bash":
+		dir, file = os.Getenv("BASH_COMPLETION_USER_DIR"), app.name
+		if dir == "" {
+			dir = filepath.Join(xdgDir("XDG_DATA_HOME", ".local", "share"), "bash-completion")
+		}
+		dir = filepath.Join(dir, "completions")
+	case "zsh":
+		dir, file = filepath.Join(xdgDir("XDG_DATA_HOME", ".local", "share"), "zsh", "site-functions"), "_"+app.name
+	case "fish":
+		dir, file = filepath.Join(xdgDir("XDG_CONFIG_HOME", ".config"), "fish", "completions"), app.name+".fish"
+	default:
+		if _, err := app.GenerateCompletionScript(shell); err != nil {
+			return "", "", err
+		}
+		return "", "", ValidationError("completion", fmt.Sprintf("%s completion cannot be installed automatically (supported: %s)",
+			shell, strings.Join(installableShells, ", "))).
+			WithContext("shell", shell).
+			WithUserMessage(fmt.Sprintf("Load the output of '%s completion %s' from your shell configuration", app.name, shell))
+	}
+
+	path := filepath.Join(dir, file)
+	if !filepath.IsAbs(path) {
+		return "", "", ExecutionError("completion", "cannot determine the home directory for the completion script")
+	}
+	result := ValidateSecurePath(path, DefaultSecurityConfig())
+	if !result.IsValid {
+		return "", "", ValidationError("completion", fmt.Sprintf("install path %s rejected: %s", path, strings.Join(result.Errors, "; "))).
+			WithContext("file", path)
+	}
+	return shell, result.NormalizedPath, nil
+}
+
+// detectShell returns the completion shell name of the user's login shell, or ""
+// when $SHELL is unset or unknown.
+func detectShell() string {
+	switch name := strings.TrimSuffix(filepath.Base(os.Getenv("SHELL")), ".exe"); name {
+	case "bash", "zsh", "fish", "elvish":
+		return name
+	case "pwsh", "powershell":
+		return "powershell"
+	case "nu":
+		return "nushell"
+	default:
+		return ""
+	}
+}
+
+// xdgDir returns the directory named by an XDG environment variable, or the given
+// path below the home directory when the variable is unset or not absolute.
+func xdgDir(env string, fallback ...string) string {
+	if dir := os.Getenv(env); filepath.IsAbs(dir) {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(append([]string{home}, fallback...)...)
+}
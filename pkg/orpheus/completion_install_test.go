@@ -0,0 +1,259 @@
+// completion_install_test.go: tests for completion script installation in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agilira/orpheus/pkg/orpheus"
+)
+
+// newInstallApp returns an app with the completion command, a per-user home in a
+// temporary directory and its output captured.
+func newInstallApp(t *testing.T, shell string) (*orpheus.App, *bytes.Buffer, string) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("BASH_COMPLETION_USER_DIR", "")
+	t.Setenv("ZDOTDIR", "")
+	t.Setenv("SHELL", shell)
+
+	var out bytes.Buffer
+	app := orpheus.New("myapp").AddCompletionCommand().SetOutput(&out)
+	return app, &out, home
+}
+
+func TestCompletionInstall(t *testing.T) {
+	app, out, home := newInstallApp(t, "/usr/bin/fish")
+	path := filepath.Join(home, ".config", "fish", "completions", "myapp.fish")
+
+	if err := app.Run([]string{"completion", "install"}); err != nil {
+		t.Fatalf("install failed: %v", err)
+	}
+	script, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("script not installed: %v", err)
+	}
+	if want := generateScript(t, app, "fish"); string(script) != want {
+		t.Errorf("installed script differs from the generated one")
+	}
+	if out.String() != "Installed fish completion in "+path+"\n" {
+		t.Errorf("output = %q", out.String())
+	}
+
+	// Installing again is a no-op
+	out.Reset()
+	if err := app.Run([]string{"completion", "install"}); err != nil {
+		t.Fatalf("second install failed: %v", err)
+	}
+	if out.String() != "fish completion is up to date in "+path+"\n" {
+		t.Errorf("output = %q", out.String())
+	}
+
+	// A stale script is replaced
+	if err := os.WriteFile(path, []byte("# old\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	if err := app.Run([]string{"completion", "install"}); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if out.String() != "Updated fish completion in "+path+"\n" {
+		t.Errorf("output = %q", out.String())
+	}
+}
+
+func TestCompletionInstallLocations(t *testing.T) {
+	tests := []struct {
+		shell string
+		env   map[string]string
+		path  []string
+	}{
+		{"bash", nil, []string{".local", "share", "bash-completion", "completions", "myapp"}},
+		{"bash", map[string]string{"XDG_DATA_HOME": "data"}, []string{"data", "bash-completion", "completions", "myapp"}},
+		{"bash", map[string]string{"BASH_COMPLETION_USER_DIR": "bash"}, []string{"bash", "completions", "myapp"}},
+		{"zsh", nil, []string{".local", "share", "zsh", "site-functions", "_myapp"}},
+		{"fish", map[string]string{"XDG_CONFIG_HOME": "config"}, []string{"config", "fish", "completions", "myapp.fish"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell+" "+strings.Join(tt.path, "/"), func(t *testing.T) {
+			app, out, home := newInstallApp(t, "")
+			for name, dir := range tt.env {
+				t.Setenv(name, filepath.Join(home, dir))
+			}
+
+			if err := app.Run([]string{"completion", "install", "--shell", tt.shell}); err != nil {
+				t.Fatalf("install failed: %v", err)
+			}
+			path := filepath.Join(append([]string{home}, tt.path...)...)
+			if _, err := os.Stat(path); err != nil {
+				t.Errorf("script not installed in %s: %v", path, err)
+			}
+			if tt.shell == "zsh" && !strings.Contains(out.String(), "Added "+filepath.Dir(path)+" to fpath in "+filepath.Join(home, ".zshrc")) {
+				t.Errorf("expected fpath to be updated, got %q", out.String())
+			}
+		})
+	}
+}
+
+func TestCompletionInstallDryRun(t *testing.T) {
+	app, out, home := newInstallApp(t, "/bin/zsh")
+	path := filepath.Join(home, ".local", "share", "zsh", "site-functions", "_myapp")
+
+	if err := app.Run([]string{"completion", "install", "--dry-run"}); err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	want := "Would install zsh completion in " + path + "\n" +
+		"Would add " + filepath.Dir(path) + " to fpath in " + filepath.Join(home, ".zshrc") + "\n"
+	if out.String() != want {
+		t.Errorf("output = %q", out.String())
+	}
+	if _, err := os.Stat(filepath.Dir(path)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("dry run should not create %s", filepath.Dir(path))
+	}
+	if _, err := os.Stat(filepath.Join(home, ".zshrc")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("dry run should not create .zshrc")
+	}
+}
+
+func TestCompletionInstallZshFpath(t *testing.T) {
+	app, out, home := newInstallApp(t, "/bin/zsh")
+	t.Setenv("ZDOTDIR", filepath.Join(home, "zsh"))
+	dir := filepath.Join(home, ".local", "share", "zsh", "site-functions")
+	zshrc := filepath.Join(home, "zsh", ".zshrc")
+	line := "fpath=('" + dir + "' $fpath); (( $+functions[compdef] )) && autoload -Uz _myapp && compdef _myapp myapp"
+
+	if err := os.MkdirAll(filepath.Dir(zshrc), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(zshrc, []byte("autoload -Uz compinit && compinit"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Installing twice adds the fpath line once
+	for i := 0; i < 2; i++ {
+		if err := app.Run([]string{"completion", "install"}); err != nil {
+			t.Fatalf("install failed: %v", err)
+		}
+	}
+	data, err := os.ReadFile(zshrc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "autoload -Uz compinit && compinit\n"+line+"\n" {
+		t.Errorf(".zshrc = %q", data)
+	}
+	if !strings.HasSuffix(out.String(), "zsh completion is up to date in "+filepath.Join(dir, "_myapp")+"\n") {
+		t.Errorf("output = %q", out.String())
+	}
+
+	out.Reset()
+	if err := app.Run([]string{"completion", "uninstall"}); err != nil {
+		t.Fatalf("uninstall failed: %v", err)
+	}
+	if out.String() != "Removed zsh completion from "+filepath.Join(dir, "_myapp")+"\nRemoved "+dir+" from fpath in "+zshrc+"\n" {
+		t.Errorf("output = %q", out.String())
+	}
+	if data, _ := os.ReadFile(zshrc); string(data) != "autoload -Uz compinit && compinit\n" {
+		t.Errorf("fpath line not removed: %q", data)
+	}
+}
+
+func TestCompletionUninstall(t *testing.T) {
+	app, out, home := newInstallApp(t, "/bin/bash")
+	path := filepath.Join(home, ".local", "share", "bash-completion", "completions", "myapp")
+
+	if err := app.Run([]string{"completion", "install"}); err != nil {
+		t.Fatalf("install failed: %v", err)
+	}
+
+	out.Reset()
+	if err := app.Run([]string{"completion", "uninstall", "-n"}); err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if out.String() != "Would remove bash completion from "+path+"\n" {
+		t.Errorf("output = %q", out.String())
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("dry run should keep the script: %v", err)
+	}
+
+	out.Reset()
+	if err := app.Run([]string{"completion", "uninstall"}); err != nil {
+		t.Fatalf("uninstall failed: %v", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("script not removed: %v", err)
+	}
+
+	out.Reset()
+	if err := app.Run([]string{"completion", "uninstall"}); err != nil {
+		t.Fatalf("second uninstall failed: %v", err)
+	}
+	if out.String() != "bash completion is not installed in "+path+"\n" {
+		t.Errorf("output = %q", out.String())
+	}
+}
+
+func TestCompletionInstallErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		shell string
+		args  []string
+		env   map[string]string
+		want  string
+	}{
+		{"undetected shell", "/bin/tcsh", nil, nil, "cannot detect the shell"},
+		{"no automatic install", "", []string{"--shell", "powershell"}, nil, "powershell completion cannot be installed automatically"},
+		{"unknown shell", "", []string{"--shell", "tcsh"}, nil, "unsupported shell: tcsh"},
+		{"denied path", "/usr/bin/fish", nil, map[string]string{"XDG_CONFIG_HOME": "/etc/xdg"}, "install path /etc/xdg/fish/completions/myapp.fish rejected"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, _, _ := newInstallApp(t, tt.shell)
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			err := app.Run(append([]string{"completion", "install"}, tt.args...))
+			var orpheusErr *orpheus.Error
+			if !errors.As(err, &orpheusErr) || !orpheusErr.IsValidationError() {
+				t.Fatalf("expected validation error, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %q should contain %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCompletionShellSubcommands(t *testing.T) {
+	app, out, _ := newInstallApp(t, "")
+
+	if err := app.Run([]string{"completion", "zsh"}); err != nil {
+		t.Fatalf("completion zsh failed: %v", err)
+	}
+	if out.String() != generateScript(t, app, "zsh") {
+		t.Errorf("unexpected zsh script:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := app.Run([]string{"completion"}); err != nil {
+		t.Fatalf("completion failed: %v", err)
+	}
+	if out.String() != generateScript(t, app, "bash") {
+		t.Errorf("completion should print the bash script by default:\n%s", out.String())
+	}
+}
@@ -0,0 +1,185 @@
+// completion_shells.go: PowerShell, Nushell and Elvish completion scripts in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+import "fmt"
+
+// generatePowerShellCompletion generates a PowerShell completion script.
+func (app *App) generatePowerShellCompletion() string {
+	return fmt.Sprintf(`# PowerShell completion for %[1]s
+Register-ArgumentCompleter -Native -CommandName '%[1]s' -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+
+    $words = @($commandAst.CommandElements |
+        Where-Object { $_.Extent.StartOffset -lt $cursorPosition } |
+        ForEach-Object { $_.ToString() })
+    $program = $words[0]
+    $arguments = @($words | Select-Object -Skip 1)
+    if ($wordToComplete -eq '') {
+        # Empty native arguments are dropped with the legacy argument passing
+        if ($PSVersionTable.PSVersion -lt [version]'7.3.0' -or $PSNativeCommandArgumentPassing -eq 'Legacy') {
+            $arguments += '""'
+        } else {
+            $arguments += ''
+        }
+    }
+
+    $out = @(& $program %[2]s @arguments 2>$null)
+    if ($out.Count -eq 0) {
+        return
+    }
+    $directive = [int]$out[-1].TrimStart(':')
+    $lines = @($out | Select-Object -SkipLast 1 | Where-Object { $_ -ne '' })
+
+    if ($directive -band (%[5]d -bor %[6]d)) {
+        # File extensions are given as suggestions; directories are always offered
+        $exts = @($lines | ForEach-Object { ($_ -split "`+"`t"+`")[0] })
+        $dir = if ($wordToComplete) { Split-Path -Parent $wordToComplete } else { '' }
+        Get-ChildItem -Path "$wordToComplete*" -ErrorAction SilentlyContinue |
+            Where-Object { $_.PSIsContainer -or (($directive -band %[5]d) -and $exts -contains $_.Extension.TrimStart('.')) } |
+            ForEach-Object {
+                $path = if ($dir) { Join-Path $dir $_.Name } else { $_.Name }
+                [System.Management.Automation.CompletionResult]::new($path, $path, 'ProviderItem', $path)
+            }
+        return
+    }
+    if ($lines.Count -eq 0) {
+        if ($directive -band %[4]d) {
+            ''
+        }
+        return
+    }
+
+    if (-not ($directive -band %[7]d)) {
+        $lines = $lines | Sort-Object
+    }
+    foreach ($line in $lines) {
+        $value, $description = $line -split "`+"`t"+`", 2
+        if (-not $description) {
+            $description = $value
+        }
+        if (-not ($directive -band %[3]d)) {
+            $value += ' '
+        }
+        [System.Management.Automation.CompletionResult]::new($value, $value.TrimEnd(), 'ParameterValue', $description)
+    }
+}
+`, app.name, completeCommandName, CompletionNoSpace, CompletionNoFiles,
+		CompletionFilterFileExt, CompletionFilterDirs, CompletionKeepOrder)
+}
+
+// generateNushellCompletion generates a Nushell completion script, to be sourced
+// from config.nu.
+func (app *App) generateNushellCompletion() string {
+	return fmt.Sprintf(`# Nushell completion for %[1]s
+#
+# Source this file from config.nu.
+
+def "nu-complete %[1]s" [context: string] {
+    let words = ($context | split row -r '\s+' | skip 1)
+    let lines = (^%[1]s %[2]s ...$words | lines)
+    if ($lines | is-empty) {
+        return null
+    }
+    let directive = ($lines | last | str replace ':' '' | into int)
+    let completions = ($lines | drop 1 | where $it != '' | each {|line|
+        let parts = ($line | split row "\t")
+        let description = if ($parts | length) > 1 { $parts | get 1 } else { '' }
+        {value: ($parts | first), description: $description}
+    })
+
+    # Files and directories are left to the built-in file completion
+    if ($directive bit-and (%[5]d bit-or %[6]d)) != 0 {
+        return null
+    }
+    if ($completions | is-empty) and ($directive bit-and %[4]d) == 0 {
+        return null
+    }
+    # Nushell decides itself whether to append a space, so NoSpace is ignored
+    {
+        options: {sort: (($directive bit-and %[7]d) == 0)}
+        completions: $completions
+    }
+}
+
+export extern "%[1]s" [
+    ...args: string@"nu-complete %[1]s"
+]
+`, app.name, completeCommandName, CompletionNoSpace, CompletionNoFiles,
+		CompletionFilterFileExt, CompletionFilterDirs, CompletionKeepOrder)
+}
+
+// generateElvishCompletion generates an Elvish completion script, to be evaluated
+// from rc.elv.
+func (app *App) generateElvishCompletion() string {
+	return fmt.Sprintf(`# Elvish completion for %[1]s
+use math
+use path
+use str
+
+set edit:completion:arg-completer[%[1]s] = {|@words|
+    var out = [(e:%[1]s %[2]s $@words[1..] 2>/dev/null)]
+    if (== (count $out) 0) {
+        return
+    }
+    var directive = (num (str:trim-prefix $out[-1] ':'))
+    var has = {|bit| == (%% (math:floor (/ $directive $bit)) 2) 1 }
+    var current = $words[-1]
+
+    var values = []
+    var descriptions = []
+    for line $out[..-1] {
+        if (!=s $line '') {
+            var parts = [(str:split "\t" $line)]
+            set values = [$@values $parts[0]]
+            if (> (count $parts) 1) {
+                set descriptions = [$@descriptions $parts[1]]
+            } else {
+                set descriptions = [$@descriptions '']
+            }
+        }
+    }
+
+    if (or ($has %[5]d) ($has %[6]d)) {
+        # File extensions are given as suggestions; directories are always offered
+        edit:complete-filename $current | each {|c|
+            var file = $c[stem]
+            if (path:is-dir $file) {
+                put $c
+            } elif ($has %[5]d) {
+                for ext $values {
+                    if (str:has-suffix $file .$ext) {
+                        put $c
+                        break
+                    }
+                }
+            }
+        }
+        return
+    }
+    if (== (count $values) 0) {
+        if (not ($has %[4]d)) {
+            edit:complete-filename $current
+        }
+        return
+    }
+
+    var suffix = ' '
+    if ($has %[3]d) {
+        set suffix = ''
+    }
+    range (count $values) | each {|i|
+        var display = $values[$i]
+        if (!=s $descriptions[$i] '') {
+            set display = $values[$i]' ('$descriptions[$i]')'
+        }
+        edit:complex-candidate $values[$i] &display=$display &code-suffix=$suffix
+    }
+}
+`, app.name, completeCommandName, CompletionNoSpace, CompletionNoFiles,
+		CompletionFilterFileExt, CompletionFilterDirs, CompletionKeepOrder)
+}
@@ -7,6 +7,12 @@
 package orpheus_test
 
 import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -78,6 +84,242 @@ func TestFlagCompletion(t *testing.T) {
 	}
 }
 
+func newCompletionApp() *orpheus.App {
+	app := orpheus.New("git").
+		AddGlobalBoolFlag("verbose", "", false, "Verbose output").
+		AddGlobalEnumFlag("color", "", "auto", []string{"auto", "always", "never"}, "Color mode")
+	add := orpheus.NewCommand("add", "Add a remote").
+		AddFlag("branch", "b", "", "Branch to track").
+		AddEnumFlag("mirror", "", "fetch", []string{"fetch", "push"}, "Mirror mode").
+		SetHandler(noop)
+	remote := orpheus.NewCommand("remote", "Manage remotes").
+		AddPersistentFlag("config", "c", "", "Remote config file").
+		AddPersistentEnumFlag("format", "", "text", []string{"text", "json"}, "Output format").
+		AddSubcommand(add).
+		AddSubcommand(orpheus.NewCommand("remove", "Remove a remote").AddAlias("rm").SetHandler(noop)).
+		AddSubcommand(orpheus.NewCommand("rename", "Rename a remote").SetHandler(noop))
+	app.AddCommand(remote)
+	return app
+}
+
+func TestSubcommandCompletion(t *testing.T) {
+	app := newCompletionApp()
+
+	tests := []struct {
+		args     []string
+		position int
+		want     []string
+	}{
+		{[]string{"remote"}, 2, []string{"add", "remove", "rename", "rm"}},
+		{[]string{"remote", "re"}, 2, []string{"remove", "rename"}},
+		{[]string{"remote", "--config", "x.yaml", "r"}, 4, []string{"remove", "rename", "rm"}},
+		{[]string{"remote", "add", "--b"}, 3, []string{"--branch"}},
+		{[]string{"remote", "add", "--"}, 3, []string{"--branch", "--color", "--config", "--format", "--help", "--mirror", "--verbose"}},
+		{[]string{"remote", "add", "--format", "j"}, 4, []string{"json"}},
+		{[]string{"remote", "--format=j"}, 2, []string{"--format=json"}},
+		{[]string{"remote", "add", "--mirror="}, 3, []string{"--mirror=fetch", "--mirror=push"}},
+		{[]string{"remote", "unknown"}, 3, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			result := app.Complete(tt.args, tt.position)
+			if !reflect.DeepEqual(result.Suggestions, tt.want) {
+				t.Errorf("suggestions = %v, want %v", result.Suggestions, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompletionAfterGlobalFlags(t *testing.T) {
+	app := newCompletionApp()
+
+	tests := []struct {
+		args     []string
+		position int
+		want     []string
+	}{
+		{[]string{"--color", "never", "remote", ""}, 4, []string{"add", "remove", "rename", "rm"}},
+		{[]string{"--verbose", "--color=always", "remote", "add", "--b"}, 5, []string{"--branch"}},
+		{[]string{"--verbose", "re"}, 2, []string{"remote"}},
+		{[]string{"--color", ""}, 2, []string{"auto", "always", "never"}},
+		{[]string{"--verbose", "--c"}, 2, []string{"--color"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			result := app.Complete(tt.args, tt.position)
+			if !reflect.DeepEqual(result.Suggestions, tt.want) {
+				t.Errorf("suggestions = %v, want %v", result.Suggestions, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubcommandCompletionRequest(t *testing.T) {
+	app := newCompletionApp()
+	add := app.GetCommands()["remote"].GetSubcommand("add")
+
+	var got *orpheus.CompletionRequest
+	add.SetCompletionHandler(func(req *orpheus.CompletionRequest) *orpheus.CompletionResult {
+		got = req
+		return &orpheus.CompletionResult{Suggestions: []string{"origin"}}
+	})
+
+	result := app.Complete([]string{"remote", "-c", "x.yaml", "add", "--branch", "main", "or"}, 7)
+	if !reflect.DeepEqual(result.Suggestions, []string{"origin"}) {
+		t.Fatalf("suggestions = %v", result.Suggestions)
+	}
+	if got.Cmd != add || got.Command != "remote add" || got.CurrentWord != "or" {
+		t.Errorf("unexpected request: command %q, current %q", got.Command, got.CurrentWord)
+	}
+	if !reflect.DeepEqual(got.Args, []string{"-c", "x.yaml", "--branch", "main", "or"}) || got.Position != 5 {
+		t.Errorf("args = %v, position = %d", got.Args, got.Position)
+	}
+}
+
+func TestHiddenCompleteCommand(t *testing.T) {
+	app := newCompletionApp()
+	app.GetCommands()["remote"].GetSubcommand("add").
+		SetCompletionHandler(func(req *orpheus.CompletionRequest) *orpheus.CompletionResult {
+			return &orpheus.CompletionResult{Suggestions: []string{"origin", "upstream"}}
+		})
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"__complete", "remote", "add", ""}, "origin\nupstream\n:0\n"},
+		{[]string{"__complete", "remote", "--format", "j"}, "json\n:2\n"},
+		{[]string{"__complete", "remote", "add", "--verbose", "--br"}, "--branch\tBranch to track\n:2\n"},
+		{[]string{"__complete", "nothing", ""}, ":0\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			var out bytes.Buffer
+			app.SetOutput(&out)
+			if err := app.Run(tt.args); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("output = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+
+	if help := app.GenerateHelp(); strings.Contains(help, "__complete") {
+		t.Errorf("__complete should not be listed in help:\n%s", help)
+	}
+}
+
+func TestCompletionDescriptionsAndDirectives(t *testing.T) {
+	app := newCompletionApp()
+	app.AddCommand(orpheus.NewCommand("load", "Load a manifest").SetHandler(noop).
+		SetCompletionHandler(func(req *orpheus.CompletionRequest) *orpheus.CompletionResult {
+			return &orpheus.CompletionResult{
+				Suggestions: []string{"yaml", "json"},
+				Directive:   orpheus.CompletionFilterFileExt | orpheus.CompletionKeepOrder,
+			}
+		}))
+
+	result := app.Complete([]string{"remote", "re"}, 2)
+	want := map[string]string{"remove": "Remove a remote", "rename": "Rename a remote"}
+	if !reflect.DeepEqual(result.Descriptions, want) {
+		t.Errorf("descriptions = %v, want %v", result.Descriptions, want)
+	}
+
+	var out bytes.Buffer
+	app.SetOutput(&out)
+	if err := app.Run([]string{"__complete", "re"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.String() != "remote\tManage remotes\n:0\n" {
+		t.Errorf("output = %q", out.String())
+	}
+
+	out.Reset()
+	if err := app.Run([]string{"__complete", "load", ""}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.String() != "yaml\njson\n:20\n" {
+		t.Errorf("output = %q", out.String())
+	}
+}
+
+func TestCompletionScriptsHonourDirectives(t *testing.T) {
+	app := orpheus.New("testapp")
+
+	tests := map[string][]string{
+		"bash": {"(( directive & 4 ))", "(( directive & 8 ))", "compopt -o nosort", `${line%%$'\t'*}`},
+		"zsh":  {`_files -g "*.(${(j:|:)exts})"`, "_files -/", "_describe -V 'completions' completions"},
+		"fish": {`__fish_complete_suffix ".$ext"`, `__fish_complete_directories "$current"`, "bitand($directive, 16)"},
+	}
+	for shell, wants := range tests {
+		script := generateScript(t, app, shell)
+		for _, want := range wants {
+			if !strings.Contains(script, want) {
+				t.Errorf("%s script should contain %q", shell, want)
+			}
+		}
+	}
+}
+
+func TestCompletionScriptsKeepColons(t *testing.T) {
+	app := orpheus.New("testapp")
+	app.AddCommand(orpheus.NewCommand("connect", "Connect to a host").SetHandler(noop).
+		SetCompletionHandler(func(req *orpheus.CompletionRequest) *orpheus.CompletionResult {
+			return &orpheus.CompletionResult{
+				Suggestions:  []string{"cache:6379", "db:5432"},
+				Descriptions: map[string]string{"db:5432": "Primary: postgres"},
+			}
+		}))
+
+	var out bytes.Buffer
+	app.SetOutput(&out)
+	if err := app.Run([]string{"__complete", "connect", ""}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	outFile := filepath.Join(t.TempDir(), "out")
+	if err := os.WriteFile(outFile, out.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The binary is replaced by a function printing the recorded __complete output
+	tests := map[string]struct {
+		stubs, run, want string
+	}{
+		"bash": {
+			stubs: `testapp() { cat "$OUT"; }`,
+			run:   `COMP_WORDS=(testapp connect ""); COMP_CWORD=2; _testapp_completion; printf '%s\n' "${COMPREPLY[@]}"`,
+			want:  "cache:6379\ndb:5432\n",
+		},
+		"zsh": {
+			stubs: `testapp() { cat "$OUT"; }; compdef() { :; }; _describe() { print -rl -- "${completions[@]}"; }`,
+			run:   `words=(testapp connect ""); CURRENT=3; _testapp`,
+			want:  "cache\\:6379\ndb\\:5432:Primary: postgres\n",
+		},
+	}
+	for shell, tt := range tests {
+		t.Run(shell, func(t *testing.T) {
+			path, err := exec.LookPath(shell)
+			if err != nil {
+				t.Skipf("%s not installed", shell)
+			}
+			script := tt.stubs + "\n" + generateScript(t, app, shell) + "\n" + tt.run
+			cmd := exec.Command(path, "-c", script)
+			cmd.Env = append(os.Environ(), "OUT="+outFile)
+			got, err := cmd.Output()
+			if err != nil {
+				t.Fatalf("%s failed: %v", shell, err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("completions = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestBashCompletionGeneration(t *testing.T) {
 	app := orpheus.New("testapp")
 	app.Command("start", "Start the service", func(ctx *orpheus.Context) error {
@@ -98,8 +340,8 @@ func TestBashCompletionGeneration(t *testing.T) {
 		t.Error("bash script should contain completion function")
 	}
 
-	if !strings.Contains(script, "start stop") {
-		t.Error("bash script should contain command names")
+	if !strings.Contains(script, `"${words[0]}" __complete "${words[@]:1:cword}"`) {
+		t.Error("bash script should call back into the binary")
 	}
 
 	if !strings.Contains(script, "complete -F") {
@@ -124,8 +366,8 @@ func TestZshCompletionGeneration(t *testing.T) {
 		t.Error("zsh script should contain completion function")
 	}
 
-	if !strings.Contains(script, "start:'Start the service'") {
-		t.Error("zsh script should contain command descriptions")
+	if !strings.Contains(script, `"${words[1]}" __complete "${(@)words[2,CURRENT]}"`) {
+		t.Error("zsh script should call back into the binary")
 	}
 }
 
@@ -142,12 +384,12 @@ func TestFishCompletionGeneration(t *testing.T) {
 		t.Error("fish script should contain complete commands")
 	}
 
-	if !strings.Contains(script, "-a start") {
-		t.Error("fish script should contain command definitions")
+	if !strings.Contains(script, `command testapp __complete $args "$current"`) {
+		t.Error("fish script should call back into the binary")
 	}
 
-	if !strings.Contains(script, "-d 'Start the service'") {
-		t.Error("fish script should contain command descriptions")
+	if !strings.Contains(script, "complete -c testapp -f -k -a '(__testapp_complete)'") {
+		t.Error("fish script should complete from the callback")
 	}
 }
 
@@ -178,3 +420,61 @@ func TestGenerateCompletionDefault(t *testing.T) {
 		t.Error("default completion should be bash format")
 	}
 }
+
+func TestGenerateCompletionUnsupportedShell(t *testing.T) {
+	app := orpheus.New("testapp").AddCompletionCommand()
+
+	script, err := app.GenerateCompletionScript("unknown")
+	var orpheusErr *orpheus.Error
+	if !errors.As(err, &orpheusErr) || !orpheusErr.IsValidationError() || script != "" {
+		t.Fatalf("expected validation error, got %q, %v", script, err)
+	}
+	if !strings.Contains(err.Error(), "supported: bash, zsh, fish, powershell, nushell, elvish") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err = app.Run([]string{"completion", "tcsh"})
+	if !errors.As(err, &orpheusErr) || !orpheusErr.IsValidationError() || !strings.Contains(err.Error(), "unsupported shell: tcsh") {
+		t.Errorf("expected unsupported shell error from the completion command, got %v", err)
+	}
+}
+
+func TestPowerShellNushellElvishCompletionGeneration(t *testing.T) {
+	app := orpheus.New("testapp")
+
+	tests := map[string][]string{
+		"powershell": {
+			"Register-ArgumentCompleter -Native -CommandName 'testapp'",
+			"& $program __complete @arguments",
+			"[System.Management.Automation.CompletionResult]::new($value, $value.TrimEnd(), 'ParameterValue', $description)",
+		},
+		"nushell": {
+			`def "nu-complete testapp" [context: string]`,
+			"^testapp __complete ...$words",
+			`...args: string@"nu-complete testapp"`,
+		},
+		"elvish": {
+			"set edit:completion:arg-completer[testapp] = {|@words|",
+			"e:testapp __complete $@words[1..]",
+			"edit:complex-candidate $values[$i] &display=$display &code-suffix=$suffix",
+		},
+	}
+	for shell, wants := range tests {
+		script := generateScript(t, app, shell)
+		for _, want := range wants {
+			if !strings.Contains(script, want) {
+				t.Errorf("%s script should contain %q:\n%s", shell, want, script)
+			}
+		}
+	}
+}
+
+// generateScript generates the completion script of a supported shell.
+func generateScript(t *testing.T, app *orpheus.App, shell string) string {
+	t.Helper()
+	script, err := app.GenerateCompletionScript(shell)
+	if err != nil {
+		t.Fatalf("GenerateCompletionScript(%q) failed: %v", shell, err)
+	}
+	return script
+}
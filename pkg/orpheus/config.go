@@ -0,0 +1,191 @@
+// config.go: layered configuration files for flags in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	flashflags "github.com/agilira/flash-flags"
+)
+
+// configFileNames are the file names searched in each configuration directory.
+var configFileNames = []string{"config.json", "config.yaml", "config.yml", "config.toml"}
+
+// EnableConfigFiles enables loading flag values from configuration files.
+// Files are layered in this order, later files overriding earlier ones:
+//  1. config.{json,yaml,yml,toml} in the user config directory ($XDG_CONFIG_HOME/<app>)
+//  2. paths added with AddConfigPath, in the order they were added
+//  3. the file given with the --config global flag (registered by this method)
+//
+// Global flags are read from top-level keys and command flags from keys
+// qualified by the command path (e.g. "remote.add.timeout").
+// Precedence is command line > environment > configuration file > default.
+func (app *App) EnableConfigFiles() *App {
+	app.configEnabled = true
+	if app.globalFlags.Lookup("config") == nil {
+		app.AddGlobalFlag("config", "", "", "Path to configuration file")
+	}
+	return app
+}
+
+// AddConfigPath adds a configuration file or directory to the configuration layers.
+// Directories are searched for config.{json,yaml,yml,toml}; missing paths are ignored.
+func (app *App) AddConfigPath(path string) *App {
+	app.configPaths = append(app.configPaths, path)
+	return app
+}
+
+// ConfigFiles returns the configuration files loaded by the last run, lowest precedence first.
+func (app *App) ConfigFiles() []string {
+	return app.configFiles
+}
+
+// configDir returns the per-user configuration directory for the application.
+func (app *App) configDir() string {
+	base := os.Getenv("XDG_CONFIG_HOME")
+	if base == "" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return ""
+		}
+		base = dir
+	}
+	return filepath.Join(base, app.name)
+}
+
+// loadConfig reads all configuration layers into app.configValues.
+func (app *App) loadConfig() error {
+	app.configValues = nil
+	app.configFiles = nil
+	if !app.configEnabled && len(app.configPaths) == 0 {
+		return nil
+	}
+
+	var files []string
+	if dir := app.configDir(); app.configEnabled && dir != "" {
+		files = append(files, findConfigFiles(dir)...)
+	}
+	for _, path := range app.configPaths {
+		files = append(files, findConfigFiles(path)...)
+	}
+
+	if app.configEnabled {
+		if explicit := app.globalFlags.GetString("config"); explicit != "" {
+			result := ValidateSecurePath(explicit, DefaultSecurityConfig())
+			if !result.IsValid {
+				return ValidationError("", fmt.Sprintf("configuration file %s rejected: %s", explicit, strings.Join(result.Errors, "; "))).
+					WithContext("file", explicit)
+			}
+			if info, err := os.Stat(result.NormalizedPath); err != nil || info.IsDir() {
+				return ValidationError("", fmt.Sprintf("configuration file %s not found", explicit)).
+					WithUserMessage(fmt.Sprintf("Configuration file %s does not exist", explicit)).
+					WithContext("file", explicit)
+			}
+			files = append(files, result.NormalizedPath)
+		}
+	}
+
+	values := make(map[string]interface{})
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return ValidationError("", fmt.Sprintf("cannot read configuration file %s: %v", file, err)).
+				WithContext("file", file)
+		}
+		parsed, err := parseConfigData(file, data)
+		if err != nil {
+			return ValidationError("", fmt.Sprintf("invalid configuration file %s: %v", file, err)).
+				WithUserMessage(fmt.Sprintf("Configuration file %s could not be parsed", file)).
+				WithContext("file", file)
+		}
+		flattenConfig("", parsed, values)
+	}
+
+	app.configValues = values
+	app.configFiles = files
+	return nil
+}
+
+// findConfigFiles returns path itself if it is a file, or the known config files inside it if it is a directory.
+func findConfigFiles(path string) []string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+	if !info.IsDir() {
+		return []string{path}
+	}
+
+	var files []string
+	for _, name := range configFileNames {
+		candidate := filepath.Join(path, name)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			files = append(files, candidate)
+		}
+	}
+	return files
+}
+
+// reloadConfig loads the configuration layers again once --config is known and
+// refills the global flags that did not come from the command line or environment.
+func (app *App) reloadConfig(sources map[string]FlagSource) error {
+	for name, source := range sources {
+		if source == FlagSourceConfig {
+			_ = app.globalFlags.ResetFlag(name)
+			delete(sources, name)
+		}
+	}
+	if err := app.loadConfig(); err != nil {
+		return err
+	}
+
+	binding := &flagBinding{
+		app:     app,
+		flags:   app.globalFlags,
+		meta:    app.globalFlagMeta,
+		sources: sources,
+	}
+	return binding.applyConfig()
+}
+
+// applyConfig fills flags not set on the command line or environment from configuration values.
+func (b *flagBinding) applyConfig() error {
+	if b.app == nil || len(b.app.configValues) == 0 {
+		return nil
+	}
+
+	var names []string
+	b.flags.VisitAll(func(flag *flashflags.Flag) {
+		if !flag.Changed() {
+			names = append(names, flag.Name())
+		}
+	})
+
+	for _, name := range names {
+		key := strings.Join(append(append([]string{}, b.scope...), name), ".")
+		raw, exists := b.app.configValues[key]
+		if !exists {
+			continue
+		}
+
+		value, err := configValueString(raw)
+		if err == nil {
+			err = setFlagValue(b.flags, name, value)
+		}
+		if err != nil {
+			return ValidationError(b.command, fmt.Sprintf("invalid value in configuration key %s for flag --%s: %v", key, name, err)).
+				WithContext("key", key).
+				WithContext("flag", name)
+		}
+		b.sources[name] = FlagSourceConfig
+	}
+
+	return nil
+}
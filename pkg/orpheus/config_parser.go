@@ -0,0 +1,417 @@
+// config_parser.go: minimal JSON/YAML/TOML configuration parsers for Orpheus application framework
+//
+// The YAML and TOML parsers intentionally support the subset needed for CLI
+// configuration files (nested tables/mappings, scalars and arrays) so that
+// Orpheus keeps its minimal dependency footprint.
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// parseConfigData parses configuration data according to the file extension.
+func parseConfigData(path string, data []byte) (map[string]interface{}, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		config := make(map[string]interface{})
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, err
+		}
+		return config, nil
+	case ".yaml", ".yml":
+		return parseYAML(string(data))
+	case ".toml":
+		return parseTOML(string(data))
+	default:
+		return nil, fmt.Errorf("unsupported configuration format %q", filepath.Ext(path))
+	}
+}
+
+// flattenConfig converts nested maps into dotted keys (e.g. "remote.add.timeout").
+func flattenConfig(prefix string, in map[string]interface{}, out map[string]interface{}) {
+	for key, value := range in {
+		fullKey := key
+		if prefix != "" {
+			fullKey = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenConfig(fullKey, nested, out)
+			continue
+		}
+		out[fullKey] = value
+	}
+}
+
+// configValueString converts a decoded configuration value to its flag string form.
+func configValueString(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			str, err := configValueString(item)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = str
+		}
+		return strings.Join(parts, ","), nil
+	case nil:
+		return "", nil
+	default:
+		return "", fmt.Errorf("unsupported value of type %T", value)
+	}
+}
+
+// =============================================================================
+// YAML SUBSET
+// =============================================================================
+
+// yamlLine is a significant (non-blank, non-comment) YAML line.
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+}
+
+// parseYAML parses block mappings, block sequences, inline arrays and scalars.
+func parseYAML(data string) (map[string]interface{}, error) {
+	var lines []yamlLine
+	for i, raw := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		if strings.Contains(raw, "\t") && strings.TrimLeft(raw, "\t ") != strings.TrimLeft(raw, " ") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", i+1)
+		}
+		text := strings.TrimRight(stripComment(raw), " ")
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" || trimmed == "---" || trimmed == "..." {
+			continue
+		}
+		lines = append(lines, yamlLine{number: i + 1, indent: len(text) - len(strings.TrimLeft(text, " ")), text: trimmed})
+	}
+
+	if len(lines) == 0 {
+		return map[string]interface{}{}, nil
+	}
+
+	value, next, err := parseYAMLBlock(lines, 0, lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if next < len(lines) {
+		return nil, fmt.Errorf("line %d: unexpected indentation", lines[next].number)
+	}
+
+	config, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("top-level value must be a mapping")
+	}
+	return config, nil
+}
+
+// parseYAMLBlock parses a mapping or sequence whose entries share the given indentation.
+func parseYAMLBlock(lines []yamlLine, pos, indent int) (interface{}, int, error) {
+	if strings.HasPrefix(lines[pos].text, "- ") || lines[pos].text == "-" {
+		return parseYAMLSequence(lines, pos, indent)
+	}
+	return parseYAMLMapping(lines, pos, indent)
+}
+
+// parseYAMLMapping parses "key: value" entries at the given indentation.
+func parseYAMLMapping(lines []yamlLine, pos, indent int) (interface{}, int, error) {
+	result := make(map[string]interface{})
+	for pos < len(lines) && lines[pos].indent == indent {
+		line := lines[pos]
+		key, rest, found := splitYAMLKey(line.text)
+		if !found {
+			return nil, pos, fmt.Errorf("line %d: expected 'key: value'", line.number)
+		}
+		pos++
+
+		if rest != "" {
+			value, err := parseScalarOrArray(rest)
+			if err != nil {
+				return nil, pos, fmt.Errorf("line %d: %v", line.number, err)
+			}
+			result[key] = value
+			continue
+		}
+
+		// Nested block (or empty value)
+		if pos < len(lines) && lines[pos].indent > indent {
+			value, next, err := parseYAMLBlock(lines, pos, lines[pos].indent)
+			if err != nil {
+				return nil, next, err
+			}
+			result[key] = value
+			pos = next
+			continue
+		}
+		result[key] = nil
+	}
+
+	if pos < len(lines) && lines[pos].indent > indent {
+		return nil, pos, fmt.Errorf("line %d: unexpected indentation", lines[pos].number)
+	}
+	return result, pos, nil
+}
+
+// parseYAMLSequence parses "- item" entries at the given indentation.
+func parseYAMLSequence(lines []yamlLine, pos, indent int) (interface{}, int, error) {
+	var result []interface{}
+	for pos < len(lines) && lines[pos].indent == indent && strings.HasPrefix(lines[pos].text+" ", "- ") {
+		line := lines[pos]
+		item := strings.TrimSpace(strings.TrimPrefix(line.text, "-"))
+		pos++
+		if item == "" {
+			return nil, pos, fmt.Errorf("line %d: nested sequence items are not supported", line.number)
+		}
+		value, err := parseScalarOrArray(item)
+		if err != nil {
+			return nil, pos, fmt.Errorf("line %d: %v", line.number, err)
+		}
+		result = append(result, value)
+	}
+	return result, pos, nil
+}
+
+// splitYAMLKey splits "key: value" into key and value, honoring quoted keys.
+func splitYAMLKey(text string) (key, rest string, found bool) {
+	if strings.HasPrefix(text, "\"") || strings.HasPrefix(text, "'") {
+		end := strings.IndexByte(text[1:], text[0])
+		if end < 0 {
+			return "", "", false
+		}
+		key = text[1 : end+1]
+		text = text[end+2:]
+		if !strings.HasPrefix(text, ":") {
+			return "", "", false
+		}
+		return key, strings.TrimSpace(text[1:]), true
+	}
+
+	idx := strings.Index(text, ": ")
+	if idx < 0 {
+		if strings.HasSuffix(text, ":") {
+			return strings.TrimSpace(strings.TrimSuffix(text, ":")), "", true
+		}
+		return "", "", false
+	}
+	return strings.TrimSpace(text[:idx]), strings.TrimSpace(text[idx+2:]), true
+}
+
+// =============================================================================
+// TOML SUBSET
+// =============================================================================
+
+// parseTOML parses tables, dotted keys, scalars and (multi-line) arrays.
+func parseTOML(data string) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	current := result
+
+	lines := strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		number := i + 1
+		line := strings.TrimSpace(stripComment(lines[i]))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[[") {
+			return nil, fmt.Errorf("line %d: arrays of tables are not supported", number)
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: malformed table header", number)
+			}
+			table, err := tomlTable(result, splitTOMLKey(line[1:len(line)-1]))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", number, err)
+			}
+			current = table
+			continue
+		}
+
+		eq := strings.IndexByte(line, '=')
+		if eq < 0 {
+			return nil, fmt.Errorf("line %d: expected 'key = value'", number)
+		}
+		keys := splitTOMLKey(line[:eq])
+		raw := strings.TrimSpace(line[eq+1:])
+
+		// Multi-line arrays continue until brackets are balanced
+		for strings.HasPrefix(raw, "[") && strings.Count(raw, "[") > strings.Count(raw, "]") && i+1 < len(lines) {
+			i++
+			raw += " " + strings.TrimSpace(stripComment(lines[i]))
+		}
+
+		value, err := parseScalarOrArray(raw)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", number, err)
+		}
+
+		table, err := tomlTable(current, keys[:len(keys)-1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", number, err)
+		}
+		table[keys[len(keys)-1]] = value
+	}
+
+	return result, nil
+}
+
+// splitTOMLKey splits a dotted key and removes quotes around each part.
+func splitTOMLKey(key string) []string {
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(part), "\"'")
+	}
+	return parts
+}
+
+// tomlTable returns the nested table addressed by keys, creating it if needed.
+func tomlTable(root map[string]interface{}, keys []string) (map[string]interface{}, error) {
+	table := root
+	for _, key := range keys {
+		existing, exists := table[key]
+		if !exists {
+			child := make(map[string]interface{})
+			table[key] = child
+			table = child
+			continue
+		}
+		child, ok := existing.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("key %q is not a table", key)
+		}
+		table = child
+	}
+	return table, nil
+}
+
+// =============================================================================
+// SHARED SCALAR PARSING
+// =============================================================================
+
+// stripComment removes a trailing "#" comment that is not inside quotes.
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// parseScalarOrArray parses an inline array ("[a, b]") or a scalar value.
+func parseScalarOrArray(raw string) (interface{}, error) {
+	if !strings.HasPrefix(raw, "[") {
+		return parseScalar(raw)
+	}
+	if !strings.HasSuffix(raw, "]") {
+		return nil, fmt.Errorf("unterminated array")
+	}
+
+	items := []interface{}{}
+	for _, part := range splitArrayItems(raw[1 : len(raw)-1]) {
+		if part == "" {
+			continue
+		}
+		value, err := parseScalar(part)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, value)
+	}
+	return items, nil
+}
+
+// splitArrayItems splits comma-separated items, ignoring commas inside quotes.
+func splitArrayItems(raw string) []string {
+	var items []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ',':
+			items = append(items, strings.TrimSpace(raw[start:i]))
+			start = i + 1
+		}
+	}
+	return append(items, strings.TrimSpace(raw[start:]))
+}
+
+// parseScalar parses quoted strings, booleans, null, integers and floats.
+// Anything else is returned as a plain string.
+func parseScalar(raw string) (interface{}, error) {
+	switch {
+	case strings.HasPrefix(raw, "\""):
+		value, err := strconv.Unquote(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quoted string %s", raw)
+		}
+		return value, nil
+	case strings.HasPrefix(raw, "'"):
+		if len(raw) < 2 || !strings.HasSuffix(raw, "'") {
+			return nil, fmt.Errorf("invalid quoted string %s", raw)
+		}
+		return strings.ReplaceAll(raw[1:len(raw)-1], "''", "'"), nil
+	}
+
+	switch raw {
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	case "null", "Null", "NULL", "~":
+		return nil, nil
+	}
+
+	number := strings.ReplaceAll(raw, "_", "")
+	if i, err := strconv.ParseInt(number, 10, 64); err == nil {
+		return i, nil
+	}
+	if f, err := strconv.ParseFloat(number, 64); err == nil && !strings.ContainsAny(raw, "aAbBcCdDfFiInN") {
+		return f, nil
+	}
+
+	return raw, nil
+}
@@ -0,0 +1,117 @@
+// config_parser_test.go: tests for configuration file parsers in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseConfigFormats(t *testing.T) {
+	expected := map[string]interface{}{
+		"name":              "demo # not a comment",
+		"debug":             true,
+		"remote.add.tags":   []interface{}{"a", "b"},
+		"remote.add.limit":  int64(5),
+		"remote.add.ratio":  0.5,
+		"remote.add.hosts":  []interface{}{"x", "y"},
+		"remote.add.quoted": "it's",
+	}
+
+	inputs := map[string]string{
+		"config.yaml": `
+---
+name: "demo # not a comment"
+debug: true   # trailing comment
+remote:
+  add:
+    tags: [a, b]
+    limit: 5
+    ratio: 0.5
+    hosts:
+      - x
+      - "y"
+    quoted: 'it''s'
+`,
+		"config.toml": `
+name = "demo # not a comment"
+debug = true
+
+[remote]
+add.tags = ["a", "b"]
+
+[remote.add]
+limit = 5
+ratio = 0.5
+hosts = [
+  "x", # first
+  "y",
+]
+quoted = 'it''s'
+`,
+		"config.json": `{"name": "demo # not a comment", "debug": true,
+"remote": {"add": {"tags": ["a", "b"], "limit": 5, "ratio": 0.5, "hosts": ["x", "y"], "quoted": "it's"}}}`,
+	}
+
+	for file, input := range inputs {
+		t.Run(file, func(t *testing.T) {
+			parsed, err := parseConfigData(file, []byte(input))
+			if err != nil {
+				t.Fatalf("parse failed: %v", err)
+			}
+			flat := make(map[string]interface{})
+			flattenConfig("", parsed, flat)
+
+			// JSON decodes every number as float64
+			if file == "config.json" {
+				flat["remote.add.limit"] = int64(flat["remote.add.limit"].(float64))
+			}
+			if !reflect.DeepEqual(flat, expected) {
+				t.Errorf("unexpected result:\n got: %#v\nwant: %#v", flat, expected)
+			}
+		})
+	}
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	cases := map[string]string{
+		"bad.yaml":  "a:\n  b: 1\n    c: 2\n",
+		"tabs.yaml": "a:\n\tb: 1\n",
+		"aot.toml":  "[[servers]]\nname = \"x\"\n",
+		"key.toml":  "just a line\n",
+		"conf.ini":  "a=1\n",
+	}
+	for file, input := range cases {
+		if _, err := parseConfigData(file, []byte(input)); err == nil {
+			t.Errorf("%s: expected parse error", file)
+		}
+	}
+}
+
+func TestConfigValueString(t *testing.T) {
+	cases := []struct {
+		value    interface{}
+		expected string
+	}{
+		{"text", "text"},
+		{true, "true"},
+		{int64(42), "42"},
+		{1.5, "1.5"},
+		{float64(30), "30"},
+		{[]interface{}{"a", int64(1)}, "a,1"},
+	}
+	for _, tc := range cases {
+		got, err := configValueString(tc.value)
+		if err != nil || got != tc.expected {
+			t.Errorf("configValueString(%v) = %q, %v; want %q", tc.value, got, err, tc.expected)
+		}
+	}
+
+	if _, err := configValueString(map[string]interface{}{"a": 1}); err == nil {
+		t.Error("expected error for table value")
+	}
+}
@@ -0,0 +1,225 @@
+// config_test.go: tests for configuration file layering in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agilira/orpheus/pkg/orpheus"
+)
+
+func writeConfigFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("failed to create config dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+// newConfigApp builds an app with a global flag and a nested "remote add" command.
+func newConfigApp(timeout *int, url, region *string, sources map[string]orpheus.FlagSource) *orpheus.App {
+	app := orpheus.New("myapp").
+		SetEnvPrefix("MYAPP").
+		AddGlobalFlag("region", "", "us-east-1", "Cloud region")
+
+	remote := orpheus.NewCommand("remote", "Manage remotes")
+	remote.Subcommand("add", "Add remote", func(ctx *orpheus.Context) error {
+		*timeout = ctx.GetFlagInt("timeout")
+		*url = ctx.GetFlagString("url")
+		*region = ctx.GetGlobalFlagString("region")
+		sources["timeout"] = ctx.FlagSource("timeout")
+		sources["url"] = ctx.FlagSource("url")
+		sources["region"] = ctx.GlobalFlagSource("region")
+		return nil
+	}).
+		AddIntFlag("timeout", "", 10, "Timeout").
+		AddFlag("url", "", "", "Remote URL")
+	app.AddCommand(remote)
+	return app
+}
+
+func TestConfigYAMLPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFile(t, dir, "config.yaml", `
+# global flags
+region: eu-west-1
+remote:
+  add:
+    timeout: 30
+    url: "https://example.com/repo.git"
+`)
+
+	var timeout int
+	var url, region string
+	sources := make(map[string]orpheus.FlagSource)
+	app := newConfigApp(&timeout, &url, &region, sources).AddConfigPath(dir)
+
+	if err := app.Run([]string{"remote", "add"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if timeout != 30 || url != "https://example.com/repo.git" || region != "eu-west-1" {
+		t.Errorf("expected values from config, got timeout=%d url=%q region=%q", timeout, url, region)
+	}
+	for _, name := range []string{"timeout", "url", "region"} {
+		if sources[name] != orpheus.FlagSourceConfig {
+			t.Errorf("expected %s from config, got %s", name, sources[name])
+		}
+	}
+
+	// Environment overrides config, command line overrides both
+	t.Setenv("MYAPP_REMOTE_ADD_TIMEOUT", "45")
+	if err := app.Run([]string{"remote", "add", "--url", "https://other.example.com"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if timeout != 45 || sources["timeout"] != orpheus.FlagSourceEnv {
+		t.Errorf("expected timeout from env, got %d (%s)", timeout, sources["timeout"])
+	}
+	if url != "https://other.example.com" || sources["url"] != orpheus.FlagSourceArgs {
+		t.Errorf("expected url from args, got %q (%s)", url, sources["url"])
+	}
+	if sources["region"].String() != "config" {
+		t.Errorf("unexpected source name %q", sources["region"].String())
+	}
+}
+
+func TestConfigFlagTOML(t *testing.T) {
+	path := writeConfigFile(t, t.TempDir(), "settings.toml", `
+region = "ap-south-1"
+
+[remote.add]
+timeout = 60 # seconds
+`)
+
+	var timeout int
+	var url, region string
+	sources := make(map[string]orpheus.FlagSource)
+	app := newConfigApp(&timeout, &url, &region, sources).EnableConfigFiles()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if err := app.Run([]string{"--config", path, "remote", "add"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if timeout != 60 || region != "ap-south-1" {
+		t.Errorf("expected values from TOML config, got timeout=%d region=%q", timeout, region)
+	}
+	if sources["url"] != orpheus.FlagSourceDefault {
+		t.Errorf("expected url default, got %s", sources["url"])
+	}
+	if files := app.ConfigFiles(); len(files) != 1 || files[0] != path {
+		t.Errorf("unexpected loaded files: %v", files)
+	}
+}
+
+func TestConfigFlagAfterCommand(t *testing.T) {
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	writeConfigFile(t, xdg, "myapp/config.json", `{"region": "eu-west-1"}`)
+	path := writeConfigFile(t, t.TempDir(), "c.yaml", "region: ap-south-1\nremote:\n  add:\n    timeout: 60\n")
+
+	var timeout int
+	var url, region string
+	sources := make(map[string]orpheus.FlagSource)
+	app := newConfigApp(&timeout, &url, &region, sources).EnableConfigFiles()
+
+	if err := app.Run([]string{"remote", "add", "--config", path}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if timeout != 60 || sources["timeout"] != orpheus.FlagSourceConfig {
+		t.Errorf("expected timeout from --config, got %d (%s)", timeout, sources["timeout"])
+	}
+	if region != "ap-south-1" || sources["region"] != orpheus.FlagSourceConfig {
+		t.Errorf("expected region from --config over user config, got %q (%s)", region, sources["region"])
+	}
+	if files := app.ConfigFiles(); len(files) != 2 || files[1] != path {
+		t.Errorf("unexpected loaded files: %v", files)
+	}
+}
+
+func TestConfigLayering(t *testing.T) {
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	writeConfigFile(t, xdg, "myapp/config.json", `{"region": "eu-west-1", "remote": {"add": {"timeout": 20}}}`)
+	explicit := writeConfigFile(t, t.TempDir(), "override.json", `{"remote.add.timeout": 90}`)
+
+	var timeout int
+	var url, region string
+	sources := make(map[string]orpheus.FlagSource)
+	app := newConfigApp(&timeout, &url, &region, sources).EnableConfigFiles()
+
+	if err := app.Run([]string{"remote", "add"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if timeout != 20 || region != "eu-west-1" {
+		t.Errorf("expected values from user config, got timeout=%d region=%q", timeout, region)
+	}
+
+	// The --config file is layered on top of the user config
+	t.Setenv("MYAPP_CONFIG", explicit)
+	if err := app.Run([]string{"remote", "add"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if timeout != 90 || region != "eu-west-1" {
+		t.Errorf("expected layered values, got timeout=%d region=%q", timeout, region)
+	}
+}
+
+func TestConfigErrors(t *testing.T) {
+	var timeout int
+	var url, region string
+	sources := make(map[string]orpheus.FlagSource)
+	app := newConfigApp(&timeout, &url, &region, sources).EnableConfigFiles()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	dir := t.TempDir()
+	err := app.Run([]string{"--config", filepath.Join(dir, "missing.yaml"), "remote", "add"})
+	requireValidationError(t, err, "not found")
+
+	bad := writeConfigFile(t, dir, "bad.yaml", "remote:\n  add:\n    timeout 30\n")
+	err = app.Run([]string{"--config", bad, "remote", "add"})
+	requireValidationError(t, err, "invalid configuration file")
+
+	wrongType := writeConfigFile(t, dir, "type.toml", "[remote.add]\ntimeout = \"soon\"\n")
+	err = app.Run([]string{"--config", wrongType, "remote", "add"})
+	requireValidationError(t, err, "remote.add.timeout")
+
+	// System paths are never loaded
+	err = app.Run([]string{"--config", "/etc/shadow", "remote", "add"})
+	requireValidationError(t, err, "--config")
+}
+
+func TestConfigKeepsPositionalArgs(t *testing.T) {
+	path := writeConfigFile(t, t.TempDir(), "c.yaml", "run:\n  name: from-config\n")
+
+	var name string
+	var args []string
+	app := orpheus.New("myapp").EnableConfigFiles()
+	app.AddCommand(orpheus.NewCommand("run", "Run").
+		AddFlag("name", "", "", "Name").
+		SetHandler(func(ctx *orpheus.Context) error {
+			name = ctx.GetFlagString("name")
+			args = ctx.Flags.Args()
+			return nil
+		}))
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if err := app.Run([]string{"--config", path, "run", "p1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "from-config" {
+		t.Errorf("expected name from config, got %q", name)
+	}
+	if len(args) != 1 || args[0] != "p1" {
+		t.Errorf("expected positional args [p1] after config binding, got %v", args)
+	}
+}
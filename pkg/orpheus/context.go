@@ -7,6 +7,11 @@
 package orpheus
 
 import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
 	flashflags "github.com/agilira/flash-flags"
 )
 
@@ -31,6 +36,16 @@ type Context struct {
 	// Storage provides access to the configured storage backend (optional)
 	// Will be nil if storage is not configured for this application
 	storage Storage
+
+	// argValues holds the converted values of declared positional arguments
+	argValues map[string]interface{}
+
+	// flagSources and globalSources record where each set flag value came from
+	flagSources   map[string]FlagSource
+	globalSources map[string]FlagSource
+
+	// stdCtx carries cancellation, deadlines and the active span for the run
+	stdCtx context.Context
 }
 
 // GetArg returns the argument at the specified index.
@@ -47,10 +62,96 @@ func (ctx *Context) ArgCount() int {
 	return len(ctx.Args)
 }
 
+// Arg returns the value of a declared positional argument as string.
+// Variadic arguments are joined with a single space.
+// Returns empty string if the argument was not provided or not declared.
+func (ctx *Context) Arg(name string) string {
+	switch value := ctx.ArgValue(name).(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case []interface{}:
+		parts := make([]string, len(value))
+		for i, item := range value {
+			parts[i] = fmt.Sprintf("%v", item)
+		}
+		return strings.Join(parts, " ")
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// ArgValue returns the converted value of a declared positional argument.
+// Variadic arguments are returned as []interface{}.
+// Returns nil if the argument was not provided or not declared.
+func (ctx *Context) ArgValue(name string) interface{} {
+	if ctx.argValues == nil {
+		return nil
+	}
+	return ctx.argValues[name]
+}
+
+// ArgInt returns a declared positional argument as int.
+func (ctx *Context) ArgInt(name string) int {
+	if value, ok := ctx.ArgValue(name).(int); ok {
+		return value
+	}
+	return 0
+}
+
+// ArgFloat64 returns a declared positional argument as float64.
+func (ctx *Context) ArgFloat64(name string) float64 {
+	if value, ok := ctx.ArgValue(name).(float64); ok {
+		return value
+	}
+	return 0.0
+}
+
+// ArgBool returns a declared positional argument as bool.
+func (ctx *Context) ArgBool(name string) bool {
+	if value, ok := ctx.ArgValue(name).(bool); ok {
+		return value
+	}
+	return false
+}
+
+// ArgDuration returns a declared positional argument as time.Duration.
+func (ctx *Context) ArgDuration(name string) time.Duration {
+	if value, ok := ctx.ArgValue(name).(time.Duration); ok {
+		return value
+	}
+	return 0
+}
+
+// ArgStrings returns the raw values of a declared positional argument as []string.
+// This is mainly useful for variadic arguments.
+func (ctx *Context) ArgStrings(name string) []string {
+	switch value := ctx.ArgValue(name).(type) {
+	case nil:
+		return []string{}
+	case []interface{}:
+		values := make([]string, len(value))
+		for i, item := range value {
+			values[i] = fmt.Sprintf("%v", item)
+		}
+		return values
+	default:
+		return []string{fmt.Sprintf("%v", value)}
+	}
+}
+
+// HasArg returns whether a declared positional argument was provided.
+func (ctx *Context) HasArg(name string) bool {
+	return ctx.ArgValue(name) != nil
+}
+
 // GetFlag returns the value of a flag as interface{}.
+// Like all GetFlag* methods it resolves flags not defined by the command through
+// the persistent flags of its parent commands and the global flags.
 func (ctx *Context) GetFlag(name string) interface{} {
-	if ctx.Flags != nil {
-		if flag := ctx.Flags.Lookup(name); flag != nil {
+	if fs := ctx.lookupFlagSet(name); fs != nil {
+		if flag := fs.Lookup(name); flag != nil {
 			return flag.Value()
 		}
 	}
@@ -59,52 +160,97 @@ func (ctx *Context) GetFlag(name string) interface{} {
 
 // GetFlagString returns a flag value as string.
 func (ctx *Context) GetFlagString(name string) string {
-	if ctx.Flags != nil {
-		return ctx.Flags.GetString(name)
+	if fs := ctx.lookupFlagSet(name); fs != nil {
+		return fs.GetString(name)
 	}
 	return ""
 }
 
 // GetFlagBool returns a flag value as bool.
 func (ctx *Context) GetFlagBool(name string) bool {
-	if ctx.Flags != nil {
-		return ctx.Flags.GetBool(name)
+	if fs := ctx.lookupFlagSet(name); fs != nil {
+		return fs.GetBool(name)
 	}
 	return false
 }
 
 // GetFlagInt returns a flag value as int.
 func (ctx *Context) GetFlagInt(name string) int {
-	if ctx.Flags != nil {
-		return ctx.Flags.GetInt(name)
+	if fs := ctx.lookupFlagSet(name); fs != nil {
+		return fs.GetInt(name)
 	}
 	return 0
 }
 
 // GetFlagFloat64 returns a flag value as float64.
 func (ctx *Context) GetFlagFloat64(name string) float64 {
-	if ctx.Flags != nil {
-		return ctx.Flags.GetFloat64(name)
+	if fs := ctx.lookupFlagSet(name); fs != nil {
+		return fs.GetFloat64(name)
 	}
 	return 0.0
 }
 
 // GetFlagStringSlice returns a flag value as []string.
 func (ctx *Context) GetFlagStringSlice(name string) []string {
-	if ctx.Flags != nil {
-		return ctx.Flags.GetStringSlice(name)
+	if fs := ctx.lookupFlagSet(name); fs != nil {
+		return fs.GetStringSlice(name)
 	}
 	return []string{}
 }
 
-// FlagChanged returns whether the specified flag was set.
+// GetFlagDuration returns a flag value as time.Duration.
+func (ctx *Context) GetFlagDuration(name string) time.Duration {
+	if fs := ctx.lookupFlagSet(name); fs != nil {
+		return fs.GetDuration(name)
+	}
+	return 0
+}
+
+// GetFlagInt64 returns a flag value as int64.
+func (ctx *Context) GetFlagInt64(name string) int64 {
+	return flagInt64(ctx.lookupFlagSet(name), name)
+}
+
+// GetFlagUint returns a flag value as uint.
+func (ctx *Context) GetFlagUint(name string) uint {
+	return flagUint(ctx.lookupFlagSet(name), name)
+}
+
+// GetFlagByteSize returns a byte size flag value in bytes.
+func (ctx *Context) GetFlagByteSize(name string) int64 {
+	return flagByteSize(ctx.lookupFlagSet(name), name)
+}
+
+// GetFlagStringMap returns a key=value flag value as map[string]string.
+func (ctx *Context) GetFlagStringMap(name string) map[string]string {
+	return flagStringMap(ctx.lookupFlagSet(name), name)
+}
+
+// GetFlagIntSlice returns a flag value as []int.
+func (ctx *Context) GetFlagIntSlice(name string) []int {
+	return flagIntSlice(ctx.lookupFlagSet(name), name)
+}
+
+// FlagChanged returns whether the specified flag was set on the command line,
+// from the environment or from a config file. Use FlagSource to tell them apart.
 func (ctx *Context) FlagChanged(name string) bool {
-	if ctx.Flags != nil {
-		return ctx.Flags.Changed(name)
+	if fs := ctx.lookupFlagSet(name); fs != nil {
+		return fs.Changed(name)
 	}
 	return false
 }
 
+// FlagSource returns where the value of the specified flag came from.
+func (ctx *Context) FlagSource(name string) FlagSource {
+	if source, exists := ctx.flagSources[name]; exists {
+		return source
+	}
+	if fs := ctx.lookupFlagSet(name); fs != nil && fs == ctx.GlobalFlags && fs != ctx.Flags {
+		return ctx.GlobalFlagSource(name)
+	}
+	return FlagSourceDefault
+}
+
 // GetGlobalFlag returns the value of a global flag.
 func (ctx *Context) GetGlobalFlag(name string) interface{} {
 	if ctx.GlobalFlags != nil {
@@ -139,6 +285,39 @@ func (ctx *Context) GetGlobalFlagInt(name string) int {
 	return 0
 }
 
+// GetGlobalFlagDuration returns a global flag value as time.Duration.
+func (ctx *Context) GetGlobalFlagDuration(name string) time.Duration {
+	if ctx.GlobalFlags != nil {
+		return ctx.GlobalFlags.GetDuration(name)
+	}
+	return 0
+}
+
+// GetGlobalFlagInt64 returns a global flag value as int64.
+func (ctx *Context) GetGlobalFlagInt64(name string) int64 {
+	return flagInt64(ctx.GlobalFlags, name)
+}
+
+// GetGlobalFlagUint returns a global flag value as uint.
+func (ctx *Context) GetGlobalFlagUint(name string) uint {
+	return flagUint(ctx.GlobalFlags, name)
+}
+
+// GetGlobalFlagByteSize returns a global byte size flag value in bytes.
+func (ctx *Context) GetGlobalFlagByteSize(name string) int64 {
+	return flagByteSize(ctx.GlobalFlags, name)
+}
+
+// GetGlobalFlagStringMap returns a global key=value flag value as map[string]string.
+func (ctx *Context) GetGlobalFlagStringMap(name string) map[string]string {
+	return flagStringMap(ctx.GlobalFlags, name)
+}
+
+// GetGlobalFlagIntSlice returns a global flag value as []int.
+func (ctx *Context) GetGlobalFlagIntSlice(name string) []int {
+	return flagIntSlice(ctx.GlobalFlags, name)
+}
+
 // GlobalFlagChanged returns whether the specified global flag was set.
 func (ctx *Context) GlobalFlagChanged(name string) bool {
 	if ctx.GlobalFlags != nil {
@@ -147,6 +326,38 @@ func (ctx *Context) GlobalFlagChanged(name string) bool {
 	return false
 }
 
+// GlobalFlagSource returns where the value of the specified global flag came from.
+func (ctx *Context) GlobalFlagSource(name string) FlagSource {
+	if source, exists := ctx.globalSources[name]; exists {
+		return source
+	}
+	return FlagSourceDefault
+}
+
+// Context returns the context.Context of the command run. It is cancelled on
+// SIGINT/SIGTERM or when the command timeout expires, and carries the active
+// tracing span. Pass it to Storage, Logger and Tracer calls.
+func (ctx *Context) Context() context.Context {
+	if ctx.stdCtx == nil {
+		return context.Background()
+	}
+	return ctx.stdCtx
+}
+
+// SetContext replaces the context.Context of the command run,
+// e.g. from middleware attaching request-scoped values.
+func (ctx *Context) SetContext(stdCtx context.Context) {
+	ctx.stdCtx = stdCtx
+}
+
+// Span returns the active tracing span, or nil if no tracer is configured.
+func (ctx *Context) Span() Span {
+	if tracer := ctx.Tracer(); tracer != nil {
+		return tracer.SpanFromContext(ctx.Context())
+	}
+	return nil
+}
+
 // Logger returns the configured logger, or nil if not set.
 func (ctx *Context) Logger() Logger {
 	if ctx.App != nil {
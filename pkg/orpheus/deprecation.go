@@ -0,0 +1,197 @@
+// deprecation.go: hidden and deprecated commands and flags in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	flashflags "github.com/agilira/flash-flags"
+)
+
+// SetHidden hides the command from help output, completion and suggestions.
+// A hidden command can still be run by its exact name or alias.
+func (c *Command) SetHidden(hidden bool) *Command {
+	c.hidden = hidden
+	return c
+}
+
+// IsHidden returns whether the command is hidden.
+func (c *Command) IsHidden() bool {
+	return c.hidden
+}
+
+// Deprecate marks the command as deprecated. Running it prints a warning with the
+// message and, when set, the replacement command. A deprecated command without a
+// handler or subcommands forwards to its replacement, resolved as a command path
+// from the application root (e.g. "list" or "remote add") or as a sibling.
+func (c *Command) Deprecate(message, replacement string) *Command {
+	c.deprecated = true
+	c.deprecationMessage = message
+	c.replacement = replacement
+	return c
+}
+
+// IsDeprecated returns whether the command is deprecated.
+func (c *Command) IsDeprecated() bool {
+	return c.deprecated
+}
+
+// Replacement returns the command that replaces a deprecated command, if any.
+func (c *Command) Replacement() string {
+	return c.replacement
+}
+
+// MarkFlagHidden hides command flags from help output and completion.
+func (c *Command) MarkFlagHidden(names ...string) *Command {
+	for _, name := range names {
+		c.flagMeta.option(name).hidden = true
+	}
+	return c
+}
+
+// MarkFlagDeprecated marks a command flag as deprecated. Using it prints a warning with the message.
+func (c *Command) MarkFlagDeprecated(name, message string) *Command {
+	opts := c.flagMeta.option(name)
+	opts.deprecated = true
+	opts.deprecationMessage = message
+	return c
+}
+
+// MarkGlobalFlagHidden hides global flags from help output and completion.
+func (app *App) MarkGlobalFlagHidden(names ...string) *App {
+	for _, name := range names {
+		app.globalFlagMeta.option(name).hidden = true
+	}
+	return app
+}
+
+// MarkGlobalFlagDeprecated marks a global flag as deprecated. Using it prints a warning with the message.
+func (app *App) MarkGlobalFlagDeprecated(name, message string) *App {
+	opts := app.globalFlagMeta.option(name)
+	opts.deprecated = true
+	opts.deprecationMessage = message
+	return app
+}
+
+// SetErrorOutput sets where warnings such as deprecation notices are written (default os.Stderr).
+func (app *App) SetErrorOutput(w io.Writer) *App {
+	app.errOutput = w
+	return app
+}
+
+// errorOutput returns the writer for warnings.
+func (app *App) errorOutput() io.Writer {
+	if app == nil || app.errOutput == nil {
+		return os.Stderr
+	}
+	return app.errOutput
+}
+
+// warn writes a warning to the error output and logs it through the configured logger.
+func (app *App) warn(ctx context.Context, message string, fields ...Field) {
+	fmt.Fprintf(app.errorOutput(), "Warning: %s\n", message)
+	if app != nil && app.logger != nil {
+		app.logger.Warn(ctx, message, fields...)
+	}
+}
+
+// warnDeprecatedCommand prints the deprecation warning of a command.
+func (c *Command) warnDeprecatedCommand(ctx *Context) {
+	message := fmt.Sprintf("command '%s' is deprecated", c.FullName())
+	if c.deprecationMessage != "" {
+		message += ": " + c.deprecationMessage
+	}
+	if c.replacement != "" {
+		message += fmt.Sprintf(" (use '%s' instead)", c.replacement)
+	}
+	ctx.App.warn(ctx.Context(), message, StringField("command", c.FullName()), StringField("replacement", c.replacement))
+}
+
+// warnDeprecatedFlags prints a warning for every deprecated flag that was set.
+func warnDeprecatedFlags(ctx *Context, command string, fs *flashflags.FlagSet, meta *flagMeta) {
+	if fs == nil || meta == nil {
+		return
+	}
+
+	var names []string
+	for name, opts := range meta.options {
+		if opts.deprecated && fs.Changed(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		message := fmt.Sprintf("flag --%s is deprecated", name)
+		if msg := meta.options[name].deprecationMessage; msg != "" {
+			message += ": " + msg
+		}
+		ctx.App.warn(ctx.Context(), message, StringField("command", command), StringField("flag", name))
+	}
+}
+
+// forwardTarget resolves the replacement of a deprecated command that has nothing
+// to run itself, or returns nil.
+func (c *Command) forwardTarget(app *App) *Command {
+	if !c.deprecated || c.replacement == "" || c.handler != nil || c.HasSubcommands() || app == nil {
+		return nil
+	}
+
+	if target := app.lookupCommandPath(strings.Fields(c.replacement)); target != nil && target != c {
+		return target
+	}
+	if c.parent != nil {
+		if target := c.parent.GetSubcommand(c.replacement); target != nil && target != c {
+			return target
+		}
+	}
+	return nil
+}
+
+// lookupCommandPath finds a command by its path of names or aliases from the application root.
+func (app *App) lookupCommandPath(path []string) *Command {
+	if len(path) == 0 {
+		return nil
+	}
+
+	var cmd *Command
+	for _, candidate := range app.commands {
+		if candidate.hasName(path[0]) {
+			cmd = candidate
+			break
+		}
+	}
+	for _, name := range path[1:] {
+		if cmd == nil {
+			return nil
+		}
+		cmd = cmd.GetSubcommand(name)
+	}
+	return cmd
+}
+
+// visibleCommands returns the non-hidden commands sorted by name.
+func visibleCommands(commands map[string]*Command) []*Command {
+	visible := make([]*Command, 0, len(commands))
+	for _, cmd := range commands {
+		if !cmd.hidden {
+			visible = append(visible, cmd)
+		}
+	}
+	sort.Slice(visible, func(i, j int) bool { return visible[i].name < visible[j].name })
+	return visible
+}
+
+// isHiddenFlag reports whether the flag options mark the flag as hidden.
+func isHiddenFlag(opts *flagOptions) bool {
+	return opts != nil && opts.hidden
+}
@@ -0,0 +1,202 @@
+// deprecation_test.go: tests for hidden and deprecated commands and flags in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/agilira/orpheus/pkg/orpheus"
+)
+
+// warnLogger records warning messages.
+type warnLogger struct {
+	warnings []string
+}
+
+func (l *warnLogger) Trace(context.Context, string, ...orpheus.Field) {}
+func (l *warnLogger) Debug(context.Context, string, ...orpheus.Field) {}
+func (l *warnLogger) Info(context.Context, string, ...orpheus.Field)  {}
+func (l *warnLogger) Error(context.Context, string, ...orpheus.Field) {}
+func (l *warnLogger) Warn(_ context.Context, msg string, _ ...orpheus.Field) {
+	l.warnings = append(l.warnings, msg)
+}
+func (l *warnLogger) WithFields(...orpheus.Field) orpheus.Logger { return l }
+
+func TestHiddenCommand(t *testing.T) {
+	ran := false
+	app := orpheus.New("testapp").SetPrefixMatching(true)
+	app.Command("status", "Show status", func(*orpheus.Context) error { return nil })
+	app.AddCommand(orpheus.NewCommand("debug-dump", "Dump internal state").
+		SetHidden(true).
+		SetHandler(func(*orpheus.Context) error {
+			ran = true
+			return nil
+		}))
+
+	if help := app.GenerateHelp(); strings.Contains(help, "debug-dump") {
+		t.Errorf("hidden command listed in help:\n%s", help)
+	}
+	if result := app.Complete([]string{"d"}, 1); len(result.Suggestions) != 0 {
+		t.Errorf("hidden command completed: %v", result.Suggestions)
+	}
+	if script := generateScript(t, app, "bash"); strings.Contains(script, "debug-dump") {
+		t.Error("hidden command included in completion script")
+	}
+
+	err := app.Run([]string{"debug-dum"})
+	if err == nil {
+		t.Fatal("hidden command matched by prefix")
+	}
+	if suggestions := errorContext(t, err)["suggestions"]; suggestions != nil {
+		t.Errorf("hidden command suggested: %v", suggestions)
+	}
+
+	if err := app.Run([]string{"debug-dump"}); err != nil || !ran {
+		t.Errorf("hidden command should run by exact name: ran=%v err=%v", ran, err)
+	}
+}
+
+func TestHiddenSubcommand(t *testing.T) {
+	app := orpheus.New("testapp")
+	remote := orpheus.NewCommand("remote", "Manage remotes")
+	remote.Subcommand("add", "Add a remote", func(*orpheus.Context) error { return nil })
+	remote.Subcommand("prune-cache", "Prune cache", func(*orpheus.Context) error { return nil }).SetHidden(true)
+	app.AddCommand(remote)
+
+	help := app.GetHelpGenerator().GenerateCommandHelp(remote)
+	if !strings.Contains(help, "add") || strings.Contains(help, "prune-cache") {
+		t.Errorf("unexpected subcommand listing:\n%s", help)
+	}
+}
+
+func TestDeprecatedCommandWarning(t *testing.T) {
+	var stderr bytes.Buffer
+	logger := &warnLogger{}
+	ran := false
+
+	app := orpheus.New("testapp").SetErrorOutput(&stderr).SetLogger(logger)
+	app.AddCommand(orpheus.NewCommand("ls", "List items").
+		Deprecate("it will be removed in v2", "list").
+		SetHandler(func(*orpheus.Context) error {
+			ran = true
+			return nil
+		}))
+
+	if err := app.Run([]string{"ls"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ran {
+		t.Error("deprecated command with a handler should still run")
+	}
+
+	want := "command 'ls' is deprecated: it will be removed in v2 (use 'list' instead)"
+	if got := stderr.String(); got != "Warning: "+want+"\n" {
+		t.Errorf("stderr = %q", got)
+	}
+	if len(logger.warnings) != 1 || logger.warnings[0] != want {
+		t.Errorf("logged warnings = %v", logger.warnings)
+	}
+	if help := app.GenerateHelp(); !strings.Contains(help, "List items (deprecated)") {
+		t.Errorf("help does not mark deprecated command:\n%s", help)
+	}
+}
+
+func TestDeprecatedCommandForwarding(t *testing.T) {
+	var stderr bytes.Buffer
+	var long bool
+	var name string
+
+	app := orpheus.New("testapp").SetErrorOutput(&stderr)
+	remote := orpheus.NewCommand("remote", "Manage remotes")
+	remote.Subcommand("add", "Add a remote", func(ctx *orpheus.Context) error {
+		name = ctx.Arg("name")
+		long = ctx.GetFlagBool("long")
+		return nil
+	}).AddArg("name", "Remote name").AddBoolFlag("long", "l", false, "Long output")
+	remote.AddSubcommand(orpheus.NewCommand("new", "Add a remote").Deprecate("", "add"))
+	app.AddCommand(remote)
+	app.AddCommand(orpheus.NewCommand("remote-add", "Add a remote").Deprecate("", "remote add"))
+
+	for _, args := range [][]string{
+		{"remote", "new", "origin", "-l"},
+		{"remote-add", "origin", "--long"},
+	} {
+		name, long = "", false
+		if err := app.Run(args); err != nil {
+			t.Fatalf("%v: unexpected error: %v", args, err)
+		}
+		if name != "origin" || !long {
+			t.Errorf("%v: forwarded with name=%q long=%v", args, name, long)
+		}
+	}
+	if !strings.Contains(stderr.String(), "command 'remote new' is deprecated (use 'add' instead)") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
+
+func TestHiddenAndDeprecatedFlags(t *testing.T) {
+	var stderr bytes.Buffer
+	var output string
+
+	app := orpheus.New("testapp").
+		SetErrorOutput(&stderr).
+		AddGlobalBoolFlag("trace", "", false, "Trace internals").
+		AddGlobalFlag("log", "", "", "Log file").
+		MarkGlobalFlagHidden("trace").
+		MarkGlobalFlagDeprecated("log", "use --log-file")
+	cmd := orpheus.NewCommand("build", "Build").
+		AddFlag("output", "o", "", "Output path").
+		AddFlag("out", "", "", "Output path").
+		AddBoolFlag("fast-path", "", false, "Experimental").
+		MarkFlagHidden("fast-path").
+		MarkFlagDeprecated("out", "use --output").
+		SetHandler(func(ctx *orpheus.Context) error {
+			output = ctx.GetFlagString("out")
+			return nil
+		})
+	app.AddCommand(cmd)
+
+	help := app.GetHelpGenerator().GenerateCommandHelp(cmd) + app.GenerateHelp()
+	if strings.Contains(help, "fast-path") || strings.Contains(help, "trace") {
+		t.Errorf("hidden flags listed in help:\n%s", help)
+	}
+	if !strings.Contains(help, "Output path (default: ) (deprecated)") {
+		t.Errorf("help does not mark deprecated flag:\n%s", help)
+	}
+
+	result := app.Complete([]string{"build", "--"}, 2)
+	for _, suggestion := range result.Suggestions {
+		if suggestion == "--fast-path" || suggestion == "--trace" {
+			t.Errorf("hidden flag completed: %v", result.Suggestions)
+		}
+	}
+
+	if err := app.Run([]string{"build", "--fast-path", "--trace"}); err != nil {
+		t.Fatalf("hidden flags should still be accepted: %v", err)
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("unexpected warnings: %q", stderr.String())
+	}
+
+	if err := app.Run([]string{"build", "--out", "bin/", "--log", "build.log"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output != "bin/" {
+		t.Errorf("out = %q", output)
+	}
+	for _, want := range []string{
+		"Warning: flag --out is deprecated: use --output\n",
+		"Warning: flag --log is deprecated: use --log-file\n",
+	} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Errorf("stderr missing %q: %q", want, stderr.String())
+		}
+	}
+}
@@ -0,0 +1,323 @@
+// docs.go: Markdown and HTML reference documentation in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	"text/template"
+)
+
+// docPage is the data rendered into a Markdown or HTML reference page.
+type docPage struct {
+	*HelpData
+	Title       string // command path, e.g. "myapp remote add"
+	Synopsis    string
+	Description []string // paragraphs
+	Deprecated  string   // deprecation notice, if any
+	Ext         string   // file extension of linked pages
+	Parent      *docLink
+	Children    []docLink
+}
+
+// docLink is a link to another reference page.
+type docLink struct {
+	Title   string
+	File    string // file name without extension
+	Summary string
+}
+
+// markdownDocTemplate renders a Markdown reference page.
+const markdownDocTemplate = `{{define "flags"}}| Flag | Shorthand | Type | Default | Description |
+|------|-----------|------|---------|-------------|
+{{range .}}| ` + "`--{{.Name}}`" + ` | {{with .Shorthand}}` + "`-{{.}}`" + `{{end}} | {{.Type}} | {{if and .HasDefault .Default}}` + "`{{cell .Default}}`" + `{{end}} | {{cell (flagInfo .)}} |
+{{end}}{{end}}
+{{- /* page */ -}}
+# {{.Title}}
+
+{{range .Description}}{{.}}
+
+{{end}}{{with .Deprecated}}> **Deprecated:** {{.}}
+
+{{end}}## Usage
+
+` + "```" + `
+{{.Synopsis}}
+` + "```" + `
+{{with .Command}}{{with .Args}}
+## Arguments
+
+| Argument | Type | Description |
+|----------|------|-------------|
+{{range .}}| ` + "`{{.Usage}}`" + ` | {{.Type}} | {{cell .Description}}{{if not .Required}} (optional){{end}} |
+{{end}}{{end}}{{end}}
+{{- range .Commands}}
+## {{.Title}}
+
+| Command | Description |
+|---------|-------------|
+{{range .Commands}}| {{if .FullName}}[{{.Name}}]({{docFile $.Title .Name}}{{$.Ext}}){{else}}{{.Name}}{{end}} | {{cell .Summary}} |
+{{end}}{{end}}
+{{- if .Flags}}
+## Flags
+
+{{template "flags" .Flags}}{{end}}
+{{- range .FlagGroups}}
+## {{.Title}}
+
+{{template "flags" .Flags}}{{end}}
+{{- with .InheritedFlags}}
+## Inherited Flags
+
+{{template "flags" .}}{{end}}
+{{- with .GlobalFlags}}
+## Global Flags
+
+{{template "flags" .}}{{end}}
+{{- with .Command}}{{with .Examples}}
+## Examples
+
+` + "```" + `
+{{range .}}{{.}}
+{{end}}` + "```" + `
+{{end}}{{end}}
+{{- if or .Parent .Children}}
+## See Also
+
+{{with .Parent}}* [{{.Title}}]({{.File}}{{$.Ext}}) - {{.Summary}}
+{{end}}{{range .Children}}* [{{.Title}}]({{.File}}{{$.Ext}}) - {{.Summary}}
+{{end}}{{end}}`
+
+// htmlDocTemplate renders an HTML reference page.
+const htmlDocTemplate = `{{define "flags"}}<table>
+<thead><tr><th>Flag</th><th>Shorthand</th><th>Type</th><th>Default</th><th>Description</th></tr></thead>
+<tbody>
+{{range .}}<tr><td><code>--{{.Name}}</code></td><td>{{with .Shorthand}}<code>-{{.}}</code>{{end}}</td><td>{{.Type}}</td><td>{{if and .HasDefault .Default}}<code>{{.Default}}</code>{{end}}</td><td>{{flagInfo .}}</td></tr>
+{{end}}</tbody>
+</table>
+{{end}}
+{{- /* page */ -}}
+<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{range .Description}}<p>{{.}}</p>
+{{end}}{{with .Deprecated}}<p><strong>Deprecated:</strong> {{.}}</p>
+{{end}}<h2>Usage</h2>
+<pre><code>{{.Synopsis}}</code></pre>
+{{with .Command}}{{with .Args}}<h2>Arguments</h2>
+<table>
+<thead><tr><th>Argument</th><th>Type</th><th>Description</th></tr></thead>
+<tbody>
+{{range .}}<tr><td><code>{{.Usage}}</code></td><td>{{.Type}}</td><td>{{.Description}}{{if not .Required}} (optional){{end}}</td></tr>
+{{end}}</tbody>
+</table>
+{{end}}{{end}}
+{{- range .Commands}}<h2>{{.Title}}</h2>
+<table>
+<thead><tr><th>Command</th><th>Description</th></tr></thead>
+<tbody>
+{{range .Commands}}<tr><td>{{if .FullName}}<a href="{{docFile $.Title .Name}}{{$.Ext}}">{{.Name}}</a>{{else}}{{.Name}}{{end}}</td><td>{{.Summary}}</td></tr>
+{{end}}</tbody>
+</table>
+{{end}}
+{{- if .Flags}}<h2>Flags</h2>
+{{template "flags" .Flags}}{{end}}
+{{- range .FlagGroups}}<h2>{{.Title}}</h2>
+{{template "flags" .Flags}}{{end}}
+{{- with .InheritedFlags}}<h2>Inherited Flags</h2>
+{{template "flags" .}}{{end}}
+{{- with .GlobalFlags}}<h2>Global Flags</h2>
+{{template "flags" .}}{{end}}
+{{- with .Command}}{{with .Examples}}<h2>Examples</h2>
+<pre><code>{{range .}}{{.}}
+{{end}}</code></pre>
+{{end}}{{end}}
+{{- if or .Parent .Children}}<h2>See Also</h2>
+<ul>
+{{with .Parent}}<li><a href="{{.File}}{{$.Ext}}">{{.Title}}</a> - {{.Summary}}</li>
+{{end}}{{range .Children}}<li><a href="{{.File}}{{$.Ext}}">{{.Title}}</a> - {{.Summary}}</li>
+{{end}}</ul>
+{{end}}</body>
+</html>
+`
+
+// docFuncs are the helper functions of the reference page templates.
+var docFuncs = map[string]interface{}{
+	"cell":     markdownCell,
+	"flagInfo": func(flag HelpFlag) string { return flagNotes(flag, false) },
+	"docFile":  func(parent, name string) string { return docFileName(parent + " " + name) },
+}
+
+var (
+	markdownDocs = template.Must(template.New("markdown").Funcs(docFuncs).Parse(markdownDocTemplate))
+	htmlDocs     = htmltemplate.Must(htmltemplate.New("html").Funcs(docFuncs).Parse(htmlDocTemplate))
+)
+
+// GenerateMarkdownDocs writes a Markdown reference page for the application and for
+// every visible command and subcommand to dir, which is created if needed. Pages
+// are named after the command path (myapp.md, myapp-remote.md, myapp-remote-add.md)
+// and link to their parent and subcommands.
+func (app *App) GenerateMarkdownDocs(dir string) error {
+	return app.generateDocs(dir, ".md", func(sb *strings.Builder, page *docPage) error {
+		return markdownDocs.Execute(sb, page)
+	})
+}
+
+// GenerateHTMLDocs writes an HTML reference page for the application and for every
+// visible command and subcommand to dir, like GenerateMarkdownDocs.
+func (app *App) GenerateHTMLDocs(dir string) error {
+	return app.generateDocs(dir, ".html", func(sb *strings.Builder, page *docPage) error {
+		return htmlDocs.Execute(sb, page)
+	})
+}
+
+// AddDocsCommand adds a hidden "docs" command that writes the reference
+// documentation: docs [--format markdown|html|man] [dir]. The directory defaults to "docs".
+func (app *App) AddDocsCommand() *App {
+	cmd := NewCommand("docs", "Generate reference documentation").
+		SetHidden(true).
+		AddEnumFlag("format", "f", "markdown", []string{"markdown", "html", "man"}, "Output format").
+		AddOptionalArg("dir", "Output directory").
+		SetHandler(func(ctx *Context) error {
+			dir := ctx.Arg("dir")
+			if dir == "" {
+				dir = "docs"
+			}
+
+			var err error
+			format := ctx.GetFlagString("format")
+			switch format {
+			case "html":
+				err = app.GenerateHTMLDocs(dir)
+			case "man":
+				err = app.GenerateManPages(dir)
+			default:
+				err = app.GenerateMarkdownDocs(dir)
+			}
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(app.stdout(), "Generated %s documentation in %s\n", format, dir)
+			return nil
+		})
+
+	app.AddCommand(cmd)
+	return app
+}
+
+// generateDocs renders a reference page per command with render and writes them to dir.
+func (app *App) generateDocs(dir, ext string, render func(*strings.Builder, *docPage) error) error {
+	dir, err := prepareDocDir(dir)
+	if err != nil {
+		return err
+	}
+
+	pages := []*docPage{app.docPage(nil, ext)}
+	for _, cmd := range app.commandTree() {
+		pages = append(pages, app.docPage(cmd, ext))
+	}
+
+	files := make(map[string]string, len(pages))
+	for _, page := range pages {
+		var sb strings.Builder
+		if err := render(&sb, page); err != nil {
+			return InternalError(fmt.Sprintf("rendering documentation for %s failed: %v", page.Title, err)).
+				WithContext("command", page.Title)
+		}
+		files[docFileName(page.Title)+ext] = sb.String()
+	}
+	return writeDocFiles(dir, files)
+}
+
+// docPage builds the reference page data of a command, or of the application when cmd is nil.
+func (app *App) docPage(cmd *Command, ext string) *docPage {
+	h := NewHelpGenerator(app)
+
+	if cmd == nil {
+		page := &docPage{
+			HelpData:    h.appHelpData(),
+			Title:       app.name,
+			Description: docParagraphs(app.description),
+			Ext:         ext,
+		}
+		page.Synopsis = page.Usage
+		for _, child := range visibleCommands(app.commands) {
+			page.Children = append(page.Children, app.docLink(child))
+		}
+		return page
+	}
+
+	page := &docPage{
+		HelpData: h.commandHelpData(cmd),
+		Title:    app.name + " " + cmd.FullName(),
+		Synopsis: strings.TrimSpace(app.name + " " + cmd.FullName() + " " + commandUsageTail(cmd)),
+		Ext:      ext,
+	}
+
+	description := cmd.Description()
+	if cmd.longDescription != "" {
+		description = cmd.longDescription
+	}
+	page.Description = docParagraphs(description)
+
+	if cmd.deprecated {
+		page.Deprecated = "this command is deprecated"
+		if cmd.deprecationMessage != "" {
+			page.Deprecated += ": " + cmd.deprecationMessage
+		}
+		if cmd.replacement != "" {
+			page.Deprecated += fmt.Sprintf(" (use %s instead)", cmd.replacement)
+		}
+	}
+
+	if cmd.parent != nil {
+		parent := app.docLink(cmd.parent)
+		page.Parent = &parent
+	} else {
+		page.Parent = &docLink{Title: app.name, File: docFileName(app.name), Summary: app.description}
+	}
+	for _, child := range visibleCommands(cmd.subcommands) {
+		page.Children = append(page.Children, app.docLink(child))
+	}
+	return page
+}
+
+// docLink returns the link to the reference page of a command.
+func (app *App) docLink(cmd *Command) docLink {
+	title := app.name + " " + cmd.FullName()
+	return docLink{Title: title, File: docFileName(title), Summary: commandSummary(cmd)}
+}
+
+// docFileName returns the file name of a reference page without extension,
+// e.g. "myapp-remote-add" for "myapp remote add".
+func docFileName(title string) string {
+	return strings.Join(strings.Fields(title), "-")
+}
+
+// docParagraphs splits text into paragraphs separated by blank lines.
+func docParagraphs(text string) []string {
+	var paragraphs []string
+	for _, paragraph := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+	return paragraphs
+}
+
+// markdownCell escapes text for a Markdown table cell.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,116 @@
+// docs_test.go: tests for Markdown and HTML reference documentation in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateMarkdownDocs(t *testing.T) {
+	dir := t.TempDir()
+	if err := newDocsApp().GenerateMarkdownDocs(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pages := readDocs(t, dir)
+
+	if len(pages) != 4 || pages["git-remote-prune.md"] != "" {
+		t.Fatalf("unexpected pages: %d", len(pages))
+	}
+
+	add := pages["git-remote-add.md"]
+	for _, want := range []string{
+		"# git remote add\n\nAdd a remote\n",
+		"## Usage\n\n```\ngit remote add [flags] <name>\n```\n",
+		"| `<name>` | string | Remote name |\n",
+		"## Flags\n\n| Flag | Shorthand | Type | Default | Description |\n",
+		"| `--mode` |  | enum | `fetch` | Mirror mode (choices: fetch, push) |\n",
+		"| `--url` | `-u` | string |  | Remote URL |\n",
+		"## Inherited Flags\n\n| Flag | Shorthand | Type | Default | Description |\n|------|-----------|------|---------|-------------|\n| `--verbose` | `-v` | bool |  | Verbose output |\n",
+		"## Global Flags\n\n| Flag | Shorthand | Type | Default | Description |\n|------|-----------|------|---------|-------------|\n| `--debug` | `-d` | bool |  | Enable debug |\n",
+		"## Examples\n\n```\ngit remote add origin https://example.com/repo.git\n```\n",
+		"## See Also\n\n* [git remote](git-remote.md) - Manage remotes\n",
+	} {
+		if !strings.Contains(add, want) {
+			t.Errorf("git-remote-add.md missing %q:\n%s", want, add)
+		}
+	}
+
+	remote := pages["git-remote.md"]
+	for _, want := range []string{
+		"Manage the set of tracked repositories.\n\nUse -v for details.\n",
+		"| [add](git-remote-add.md) | Add a remote |\n",
+		"* [git](git.md) - Content tracker\n* [git remote add](git-remote-add.md) - Add a remote\n",
+	} {
+		if !strings.Contains(remote, want) {
+			t.Errorf("git-remote.md missing %q:\n%s", want, remote)
+		}
+	}
+
+	if root := pages["git.md"]; !strings.Contains(root, "| [status](git-status.md) | Show the working tree status |\n| help | Show help for commands |\n") {
+		t.Errorf("git.md missing command table:\n%s", root)
+	}
+}
+
+func TestGenerateHTMLDocs(t *testing.T) {
+	dir := t.TempDir()
+	app := newDocsApp()
+	app.GetCommands()["status"].SetLongDescription("Shows <staged> & unstaged changes.")
+	if err := app.GenerateHTMLDocs(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pages := readDocs(t, dir)
+
+	if status := pages["git-status.html"]; !strings.Contains(status, "<p>Shows &lt;staged&gt; &amp; unstaged changes.</p>") {
+		t.Errorf("description not escaped:\n%s", status)
+	}
+
+	add := pages["git-remote-add.html"]
+	for _, want := range []string{
+		"<title>git remote add</title>",
+		"<pre><code>git remote add [flags] &lt;name&gt;</code></pre>",
+		"<tr><td><code>--url</code></td><td><code>-u</code></td><td>string</td><td></td><td>Remote URL</td></tr>",
+		`<li><a href="git-remote.html">git remote</a> - Manage remotes</li>`,
+	} {
+		if !strings.Contains(add, want) {
+			t.Errorf("git-remote-add.html missing %q:\n%s", want, add)
+		}
+	}
+	if !strings.Contains(pages["git-remote.html"], `<a href="git-remote-add.html">add</a>`) {
+		t.Errorf("git-remote.html missing subcommand link:\n%s", pages["git-remote.html"])
+	}
+}
+
+func TestDocsCommand(t *testing.T) {
+	app := newDocsApp().AddDocsCommand()
+	dir := filepath.Join(t.TempDir(), "reference")
+
+	if strings.Contains(app.GenerateHelp(), "docs") {
+		t.Error("docs command should be hidden")
+	}
+	if err := app.Run([]string{"docs", "--format", "html", dir}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "git-remote-add.html")); err != nil {
+		t.Errorf("html docs not generated: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "git-docs.html")); err == nil {
+		t.Error("hidden docs command documented")
+	}
+
+	if err := app.Run([]string{"docs", "-f", "man", dir}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "git-remote-add.1")); err != nil {
+		t.Errorf("man pages not generated: %v", err)
+	}
+
+	err := app.Run([]string{"docs", "--format", "pdf", dir})
+	requireValidationError(t, err, `invalid value "pdf"`)
+}
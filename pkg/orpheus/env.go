@@ -0,0 +1,170 @@
+// env.go: environment variable binding for flags in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	flashflags "github.com/agilira/flash-flags"
+)
+
+// SetEnvPrefix enables environment variable binding for all flags using the given prefix.
+// Global flags are read from PREFIX_FLAG_NAME and command flags from
+// PREFIX_COMMAND_PATH_FLAG_NAME (e.g. MYAPP_REMOTE_ADD_TIMEOUT for "remote add --timeout").
+// Values from the command line always take precedence over the environment.
+func (app *App) SetEnvPrefix(prefix string) *App {
+	app.envPrefix = strings.TrimSuffix(prefix, "_")
+	return app
+}
+
+// EnvPrefix returns the configured environment variable prefix.
+func (app *App) EnvPrefix() string {
+	return app.envPrefix
+}
+
+// SetGlobalFlagEnv binds a global flag to a specific environment variable,
+// overriding the name derived from the prefix.
+func (app *App) SetGlobalFlagEnv(flagName, envVar string) *App {
+	app.globalFlagMeta.option(flagName).envVar = envVar
+	return app
+}
+
+// SetInputValidator sets the validator used to check values read from the environment.
+// By default a validator with DefaultValidationConfig is used.
+func (app *App) SetInputValidator(validator *InputValidator) *App {
+	app.inputValidator = validator
+	return app
+}
+
+// InputValidator returns the validator used for environment values, creating the default one if needed.
+func (app *App) InputValidator() *InputValidator {
+	if app.inputValidator == nil {
+		app.inputValidator = NewInputValidator(DefaultValidationConfig())
+	}
+	return app.inputValidator
+}
+
+// SetFlagEnv binds a command flag to a specific environment variable,
+// overriding the name derived from the application prefix.
+func (c *Command) SetFlagEnv(flagName, envVar string) *Command {
+	c.flagMeta.option(flagName).envVar = envVar
+	return c
+}
+
+// envVarName returns the environment variable bound to a flag, or "" if none.
+// The scope is the command path the flag belongs to (empty for global flags).
+func envVarName(prefix string, scope []string, flagName string, opts *flagOptions) string {
+	if opts != nil && opts.envVar != "" {
+		return opts.envVar
+	}
+	if prefix == "" {
+		return ""
+	}
+
+	parts := append([]string{prefix}, scope...)
+	parts = append(parts, flagName)
+	name := strings.Join(parts, "_")
+	return strings.ToUpper(strings.NewReplacer("-", "_", ".", "_", " ", "_").Replace(name))
+}
+
+// flagBinding describes a parsed flag set that can be filled from external sources.
+type flagBinding struct {
+	app     *App
+	command string   // command name used in errors ("" for global flags)
+	scope   []string // command path used to derive env names and config keys
+	flags   *flashflags.FlagSet
+	meta    *flagMeta
+	sources map[string]FlagSource
+}
+
+// recordArgs marks every flag set during argv parsing as coming from the command line.
+func (b *flagBinding) recordArgs() {
+	b.flags.VisitAll(func(flag *flashflags.Flag) {
+		if flag.Changed() {
+			b.sources[flag.Name()] = FlagSourceArgs
+		}
+	})
+}
+
+// applyEnvironment fills flags not set on the command line from their environment variables.
+// Each value is checked with InputValidator.ValidateEnvironmentValue before being applied.
+func (b *flagBinding) applyEnvironment() error {
+	prefix := ""
+	if b.app != nil {
+		prefix = b.app.envPrefix
+	}
+
+	var names []string
+	b.flags.VisitAll(func(flag *flashflags.Flag) {
+		if !flag.Changed() {
+			names = append(names, flag.Name())
+		}
+	})
+
+	for _, name := range names {
+		envName := envVarName(prefix, b.scope, name, b.meta.lookup(name))
+		if envName == "" {
+			continue
+		}
+
+		value, exists := os.LookupEnv(envName)
+		if !exists || value == "" {
+			continue
+		}
+
+		sanitized, err := b.validateEnvValue(envName, value)
+		if err != nil {
+			return err
+		}
+
+		if err := setFlagValue(b.flags, name, sanitized); err != nil {
+			return ValidationError(b.command, fmt.Sprintf("invalid value in environment variable %s for flag --%s: %v", envName, name, err)).
+				WithContext("env", envName).
+				WithContext("flag", name)
+		}
+		b.sources[name] = FlagSourceEnv
+	}
+
+	return nil
+}
+
+// validateEnvValue checks an environment value and returns the sanitized value to use.
+func (b *flagBinding) validateEnvValue(envName, value string) (string, error) {
+	validator := NewInputValidator(DefaultValidationConfig())
+	var logger Logger
+	logCtx := context.Background()
+	if b.app != nil {
+		validator = b.app.InputValidator()
+		logger = b.app.logger
+		logCtx = b.app.runContext()
+	}
+
+	result := validator.ValidateEnvironmentValue(envName, value)
+	if !result.IsValid {
+		return "", ValidationError(b.command, fmt.Sprintf("environment variable %s rejected: %s", envName, strings.Join(result.ValidationErrors, "; "))).
+			WithUserMessage(fmt.Sprintf("Environment variable %s contains an invalid value", envName)).
+			WithContext("env", envName)
+	}
+
+	if logger != nil && len(result.SecurityWarnings) > 0 {
+		logger.Debug(logCtx, "Environment value accepted with warnings",
+			Field{Key: "env", Value: envName},
+			Field{Key: "warnings", Value: strings.Join(result.SecurityWarnings, "; ")})
+	}
+
+	return result.SanitizedValue, nil
+}
+
+// setFlagValue sets a single flag from its string representation using flash-flags parsing,
+// so type conversion, security checks and validators behave exactly as on the command line.
+// Parse resets the positional arguments, so the current ones are passed back after "--".
+func setFlagValue(fs *flashflags.FlagSet, name, value string) error {
+	return fs.Parse(append([]string{"--" + name + "=" + value, "--"}, fs.Args()...))
+}
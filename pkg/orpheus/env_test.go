@@ -0,0 +1,212 @@
+// env_test.go: tests for environment variable binding in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus_test
+
+import (
+	"testing"
+
+	"github.com/agilira/orpheus/pkg/orpheus"
+)
+
+func TestEnvPrefixFillsCommandFlags(t *testing.T) {
+	t.Setenv("MYAPP_DEPLOY_REGION", "eu-west-1")
+	t.Setenv("MYAPP_DEPLOY_REPLICAS", "3")
+
+	var region string
+	var replicas int
+	var regionSource, replicasSource orpheus.FlagSource
+
+	app := orpheus.New("myapp").SetEnvPrefix("MYAPP_")
+	app.AddCommand(orpheus.NewCommand("deploy", "Deploy").
+		AddFlag("region", "r", "us-east-1", "Region").
+		AddIntFlag("replicas", "", 1, "Replicas").
+		SetHandler(func(ctx *orpheus.Context) error {
+			region = ctx.GetFlagString("region")
+			replicas = ctx.GetFlagInt("replicas")
+			regionSource = ctx.FlagSource("region")
+			replicasSource = ctx.FlagSource("replicas")
+			return nil
+		}))
+
+	if err := app.Run([]string{"deploy"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if region != "eu-west-1" || regionSource != orpheus.FlagSourceEnv {
+		t.Errorf("expected region from env, got %q (%s)", region, regionSource)
+	}
+	if replicas != 3 || replicasSource != orpheus.FlagSourceEnv {
+		t.Errorf("expected replicas from env, got %d (%s)", replicas, replicasSource)
+	}
+
+	// Command line wins over environment
+	if err := app.Run([]string{"deploy", "--region", "ap-south-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if region != "ap-south-1" || regionSource != orpheus.FlagSourceArgs {
+		t.Errorf("expected region from args, got %q (%s)", region, regionSource)
+	}
+}
+
+func TestEnvDefaultSource(t *testing.T) {
+	var source orpheus.FlagSource
+	var changed bool
+
+	app := orpheus.New("myapp").SetEnvPrefix("MYAPP")
+	app.AddCommand(orpheus.NewCommand("deploy", "Deploy").
+		AddFlag("region", "", "us-east-1", "Region").
+		SetHandler(func(ctx *orpheus.Context) error {
+			source = ctx.FlagSource("region")
+			changed = ctx.FlagChanged("region")
+			return nil
+		}))
+
+	if err := app.Run([]string{"deploy"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if source != orpheus.FlagSourceDefault || changed {
+		t.Errorf("expected default source, got %s (changed=%v)", source, changed)
+	}
+	if source.String() != "default" {
+		t.Errorf("unexpected source name %q", source.String())
+	}
+}
+
+func TestEnvSubcommandAndOverride(t *testing.T) {
+	t.Setenv("MYAPP_REMOTE_ADD_TIMEOUT", "45")
+	t.Setenv("REMOTE_URL", "https://example.com/repo.git")
+
+	var timeout int
+	var url string
+
+	app := orpheus.New("myapp").SetEnvPrefix("MYAPP")
+	remote := orpheus.NewCommand("remote", "Manage remotes")
+	remote.Subcommand("add", "Add remote", func(ctx *orpheus.Context) error {
+		timeout = ctx.GetFlagInt("timeout")
+		url = ctx.GetFlagString("url")
+		return nil
+	}).
+		AddIntFlag("timeout", "", 10, "Timeout").
+		AddFlag("url", "", "", "Remote URL").
+		SetFlagEnv("url", "REMOTE_URL")
+	app.AddCommand(remote)
+
+	if err := app.Run([]string{"remote", "add"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if timeout != 45 {
+		t.Errorf("expected timeout 45 from env, got %d", timeout)
+	}
+	if url != "https://example.com/repo.git" {
+		t.Errorf("expected url from override env var, got %q", url)
+	}
+}
+
+func TestEnvGlobalFlags(t *testing.T) {
+	t.Setenv("MYAPP_VERBOSE", "true")
+	t.Setenv("API_TOKEN", "secret")
+
+	var verbose bool
+	var token string
+	var verboseSource, tokenSource orpheus.FlagSource
+
+	app := orpheus.New("myapp").
+		SetEnvPrefix("MYAPP").
+		AddGlobalBoolFlag("verbose", "", false, "Verbose output").
+		AddGlobalFlag("token", "", "", "API token").
+		SetGlobalFlagEnv("token", "API_TOKEN").
+		MarkGlobalFlagRequired("token")
+	app.Command("status", "Show status", func(ctx *orpheus.Context) error {
+		verbose = ctx.GetGlobalFlagBool("verbose")
+		token = ctx.GetGlobalFlagString("token")
+		verboseSource = ctx.GlobalFlagSource("verbose")
+		tokenSource = ctx.GlobalFlagSource("token")
+		return nil
+	})
+
+	if err := app.Run([]string{"status"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !verbose || verboseSource != orpheus.FlagSourceEnv {
+		t.Errorf("expected verbose from env, got %v (%s)", verbose, verboseSource)
+	}
+	if token != "secret" || tokenSource != orpheus.FlagSourceEnv {
+		t.Errorf("expected token from env, got %q (%s)", token, tokenSource)
+	}
+}
+
+func TestEnvInvalidValues(t *testing.T) {
+	app := orpheus.New("myapp").SetEnvPrefix("MYAPP")
+	app.AddCommand(orpheus.NewCommand("deploy", "Deploy").
+		AddIntFlag("replicas", "", 1, "Replicas").
+		AddFlag("target", "", "", "Target").
+		SetHandler(func(ctx *orpheus.Context) error { return nil }))
+
+	t.Setenv("MYAPP_DEPLOY_REPLICAS", "many")
+	err := app.Run([]string{"deploy"})
+	requireValidationError(t, err, "MYAPP_DEPLOY_REPLICAS")
+
+	// Untrusted variables with injection patterns are rejected by the input validator
+	t.Setenv("MYAPP_DEPLOY_REPLICAS", "")
+	t.Setenv("MYAPP_DEPLOY_TARGET", "prod; rm -rf /")
+	err = app.Run([]string{"deploy"})
+	requireValidationError(t, err, "MYAPP_DEPLOY_TARGET")
+}
+
+func TestEnvTrustedPrefix(t *testing.T) {
+	t.Setenv("MYAPP_DEPLOY_TARGET", "group{a}")
+
+	config := orpheus.DefaultValidationConfig()
+	config.TrustedEnvPrefixes = append(config.TrustedEnvPrefixes, "MYAPP_")
+
+	var target string
+	app := orpheus.New("myapp").
+		SetEnvPrefix("MYAPP").
+		SetInputValidator(orpheus.NewInputValidator(config))
+	app.AddCommand(orpheus.NewCommand("deploy", "Deploy").
+		AddFlag("target", "", "", "Target").
+		SetHandler(func(ctx *orpheus.Context) error {
+			target = ctx.GetFlagString("target")
+			return nil
+		}))
+
+	if err := app.Run([]string{"deploy"}); err != nil {
+		t.Fatalf("trusted env value should be accepted: %v", err)
+	}
+	if target != "group{a}" {
+		t.Errorf("expected target from trusted env var, got %q", target)
+	}
+
+	// The same value is rejected when the prefix is not trusted
+	app.SetInputValidator(orpheus.NewInputValidator(orpheus.DefaultValidationConfig()))
+	err := app.Run([]string{"deploy"})
+	requireValidationError(t, err, "MYAPP_DEPLOY_TARGET")
+}
+
+func TestEnvKeepsPositionalArgs(t *testing.T) {
+	t.Setenv("MYAPP_RUN_NAME", "x")
+
+	var name string
+	var args []string
+	app := orpheus.New("myapp").SetEnvPrefix("MYAPP")
+	app.AddCommand(orpheus.NewCommand("run", "Run").
+		AddFlag("name", "", "", "Name").
+		SetHandler(func(ctx *orpheus.Context) error {
+			name = ctx.GetFlagString("name")
+			args = ctx.Flags.Args()
+			return nil
+		}))
+
+	if err := app.Run([]string{"run", "a", "b"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "x" {
+		t.Errorf("expected name from env, got %q", name)
+	}
+	if len(args) != 2 || args[0] != "a" || args[1] != "b" {
+		t.Errorf("expected positional args [a b] after env binding, got %v", args)
+	}
+}
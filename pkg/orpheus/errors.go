@@ -32,6 +32,7 @@ const (
 type Error struct {
 	goError *goerrors.Error
 	Command string
+	hint    string // appended to the message, e.g. "did you mean" suggestions
 }
 
 // NewError creates a new enhanced Error using go-errors framework
@@ -48,10 +49,14 @@ func NewError(code goerrors.ErrorCode, command, message string) *Error {
 
 // Error implements the error interface with enhanced formatting
 func (e *Error) Error() string {
+	msg := e.goError.Error()
+	if e.hint != "" {
+		msg += ". " + e.hint
+	}
 	if e.Command != "" {
-		return fmt.Sprintf("command '%s': %s", e.Command, e.goError.Error())
+		return fmt.Sprintf("command '%s': %s", e.Command, msg)
 	}
-	return e.goError.Error()
+	return msg
 }
 
 // ErrorCode returns the error code from the underlying go-errors
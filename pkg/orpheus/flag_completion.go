@@ -0,0 +1,94 @@
+// flag_completion.go: per-flag value completion in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+import (
+	"strings"
+)
+
+// SetFlagCompletion sets the handler completing the values of a flag, called when
+// the cursor is on the word after the flag (--region <TAB>) or after "--region=".
+// The request has Type CompletionFlagValues and Flag set to the flag name. Works
+// for the command's own and persistent flags; see CompleteFiles, CompleteDirs and
+// CompleteValues for common handlers.
+func (c *Command) SetFlagCompletion(flagName string, handler CompletionHandler) *Command {
+	meta := c.flagMeta
+	if c.persistent != nil && c.persistent.Lookup(flagName) != nil {
+		meta = c.persistentMeta
+	}
+	meta.option(flagName).completion = handler
+	return c
+}
+
+// SetGlobalFlagCompletion sets the handler completing the values of a global flag.
+func (app *App) SetGlobalFlagCompletion(flagName string, handler CompletionHandler) *App {
+	app.globalFlagMeta.option(flagName).completion = handler
+	return app
+}
+
+// CompleteFiles returns a completion handler for file names, limited to the given
+// extensions (without the leading dot) when any are given.
+func CompleteFiles(extensions ...string) CompletionHandler {
+	return func(req *CompletionRequest) *CompletionResult {
+		if len(extensions) == 0 {
+			return &CompletionResult{Suggestions: []string{}, Directive: CompletionDefault}
+		}
+		suggestions := make([]string, len(extensions))
+		for i, ext := range extensions {
+			suggestions[i] = strings.TrimPrefix(ext, ".")
+		}
+		return &CompletionResult{Suggestions: suggestions, Directive: CompletionFilterFileExt}
+	}
+}
+
+// CompleteDirs returns a completion handler for directory names.
+func CompleteDirs() CompletionHandler {
+	return func(req *CompletionRequest) *CompletionResult {
+		return &CompletionResult{Suggestions: []string{}, Directive: CompletionFilterDirs}
+	}
+}
+
+// CompleteValues returns a completion handler suggesting the given values that
+// start with the current word, in the given order.
+func CompleteValues(values ...string) CompletionHandler {
+	return func(req *CompletionRequest) *CompletionResult {
+		suggestions := []string{}
+		for _, value := range values {
+			if strings.HasPrefix(value, req.CurrentWord) {
+				suggestions = append(suggestions, value)
+			}
+		}
+		return &CompletionResult{Suggestions: suggestions, Directive: CompletionNoFiles | CompletionKeepOrder}
+	}
+}
+
+// prefixCompletions prepends the "--flag=" prefix of an inline flag value to the
+// suggestions of a flag completion handler. File and directory completions are
+// left to the shell unchanged.
+func prefixCompletions(prefix string, result *CompletionResult) *CompletionResult {
+	if result == nil {
+		return &CompletionResult{Suggestions: []string{}}
+	}
+	if prefix == "" || result.Directive&(CompletionFilterFileExt|CompletionFilterDirs) != 0 {
+		return result
+	}
+
+	prefixed := &CompletionResult{
+		Suggestions: make([]string, len(result.Suggestions)),
+		Directive:   result.Directive,
+	}
+	if result.Descriptions != nil {
+		prefixed.Descriptions = make(map[string]string, len(result.Descriptions))
+	}
+	for i, suggestion := range result.Suggestions {
+		prefixed.Suggestions[i] = prefix + suggestion
+		if description, ok := result.Descriptions[suggestion]; ok {
+			prefixed.Descriptions[prefix+suggestion] = description
+		}
+	}
+	return prefixed
+}
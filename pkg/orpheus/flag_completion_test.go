@@ -0,0 +1,131 @@
+// flag_completion_test.go: tests for per-flag value completion in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/agilira/orpheus/pkg/orpheus"
+)
+
+func newFlagCompletionApp(req **orpheus.CompletionRequest) *orpheus.App {
+	app := orpheus.New("cloud").
+		AddGlobalFlag("profile", "p", "", "Credentials profile").
+		SetGlobalFlagCompletion("profile", orpheus.CompleteValues("default", "dev", "prod"))
+	deploy := orpheus.NewCommand("deploy", "Deploy the stack").
+		AddFlag("region", "r", "", "Target region").
+		AddFlag("config", "c", "", "Stack configuration").
+		AddFlag("workdir", "", "", "Working directory").
+		AddFlag("name", "", "", "Stack name").
+		AddBoolFlag("wait", "w", false, "Wait for completion").
+		SetFlagCompletion("region", func(r *orpheus.CompletionRequest) *orpheus.CompletionResult {
+			*req = r
+			return &orpheus.CompletionResult{
+				Suggestions:  []string{"eu-west-1", "us-east-1"},
+				Descriptions: map[string]string{"eu-west-1": "Ireland"},
+			}
+		}).
+		SetFlagCompletion("config", orpheus.CompleteFiles("yaml", ".json")).
+		SetFlagCompletion("workdir", orpheus.CompleteDirs()).
+		SetCompletionHandler(func(*orpheus.CompletionRequest) *orpheus.CompletionResult {
+			return &orpheus.CompletionResult{Suggestions: []string{"stack"}}
+		}).
+		SetHandler(noop)
+	stack := orpheus.NewCommand("stack", "Manage stacks").
+		AddPersistentFlag("env", "e", "", "Environment").
+		SetFlagCompletion("env", orpheus.CompleteValues("staging", "production")).
+		AddSubcommand(orpheus.NewCommand("show", "Show a stack").
+			AddFlag("format", "f", "", "Output format").
+			SetFlagCompletion("format", func(r *orpheus.CompletionRequest) *orpheus.CompletionResult {
+				*req = r
+				return &orpheus.CompletionResult{Suggestions: []string{"json", "yaml"}}
+			}).
+			SetHandler(noop))
+	app.AddCommand(deploy).AddCommand(stack)
+	return app
+}
+
+func TestFlagValueCompletion(t *testing.T) {
+	var req *orpheus.CompletionRequest
+	app := newFlagCompletionApp(&req)
+
+	tests := []struct {
+		args      []string
+		position  int
+		want      []string
+		directive orpheus.CompletionDirective
+	}{
+		{[]string{"deploy", "--region", ""}, 3, []string{"eu-west-1", "us-east-1"}, orpheus.CompletionDefault},
+		{[]string{"deploy", "-r", "eu"}, 3, []string{"eu-west-1", "us-east-1"}, orpheus.CompletionDefault},
+		{[]string{"deploy", "--region=e"}, 2, []string{"--region=eu-west-1", "--region=us-east-1"}, orpheus.CompletionDefault},
+		{[]string{"deploy", "--config", ""}, 3, []string{"yaml", "json"}, orpheus.CompletionFilterFileExt},
+		{[]string{"deploy", "--workdir", ""}, 3, []string{}, orpheus.CompletionFilterDirs},
+		{[]string{"deploy", "--name", ""}, 3, []string{}, orpheus.CompletionDefault},
+		{[]string{"deploy", "--wait", ""}, 3, []string{"stack"}, orpheus.CompletionDefault},
+		{[]string{"deploy", "--profile", "d"}, 3, []string{"default", "dev"}, orpheus.CompletionNoFiles | orpheus.CompletionKeepOrder},
+		{[]string{"stack", "show", "--env", ""}, 4, []string{"staging", "production"}, orpheus.CompletionNoFiles | orpheus.CompletionKeepOrder},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			result := app.Complete(tt.args, tt.position)
+			if !reflect.DeepEqual(result.Suggestions, tt.want) {
+				t.Errorf("suggestions = %v, want %v", result.Suggestions, tt.want)
+			}
+			if result.Directive != tt.directive {
+				t.Errorf("directive = %v, want %v", result.Directive, tt.directive)
+			}
+		})
+	}
+}
+
+func TestFlagValueCompletionRequest(t *testing.T) {
+	var req *orpheus.CompletionRequest
+	app := newFlagCompletionApp(&req)
+
+	result := app.Complete([]string{"deploy", "--wait", "--region=eu"}, 3)
+	if req == nil {
+		t.Fatal("flag completion handler not called")
+	}
+	if req.Type != orpheus.CompletionFlagValues || req.Flag != "region" || req.CurrentWord != "eu" || req.Command != "deploy" {
+		t.Errorf("unexpected request: type %v, flag %q, current %q, command %q", req.Type, req.Flag, req.CurrentWord, req.Command)
+	}
+	if result.Descriptions["--region=eu-west-1"] != "Ireland" {
+		t.Errorf("descriptions = %v", result.Descriptions)
+	}
+}
+
+func TestFlagValueCompletionRequestArgs(t *testing.T) {
+	var req *orpheus.CompletionRequest
+	app := newFlagCompletionApp(&req)
+
+	tests := []struct {
+		args     []string
+		position int
+		want     []string
+		pos      int
+	}{
+		{[]string{"stack", "show", "web", "--format", "", "extra"}, 5, []string{"web", "--format", "", "extra"}, 3},
+		{[]string{"stack", "--env", "prod", "show", "-f", "j"}, 6, []string{"--env", "prod", "-f", "j"}, 4},
+		{[]string{"stack", "show", "--format=y"}, 3, []string{"--format=y"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			req = nil
+			app.Complete(tt.args, tt.position)
+			if req == nil {
+				t.Fatal("flag completion handler not called")
+			}
+			if req.Command != "stack show" || !reflect.DeepEqual(req.Args, tt.want) || req.Position != tt.pos {
+				t.Errorf("request command %q, args %q, position %d; want %q, %d", req.Command, req.Args, req.Position, tt.want, tt.pos)
+			}
+		})
+	}
+}
@@ -0,0 +1,324 @@
+// flags.go: flag metadata and constraints in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	flashflags "github.com/agilira/flash-flags"
+)
+
+// FlagSource identifies where the value of a flag came from.
+type FlagSource int
+
+const (
+	// FlagSourceDefault indicates the flag kept its default value
+	FlagSourceDefault FlagSource = iota
+	// FlagSourceConfig indicates the value was read from a configuration file
+	FlagSourceConfig
+	// FlagSourceEnv indicates the value was read from an environment variable
+	FlagSourceEnv
+	// FlagSourceArgs indicates the value was provided on the command line
+	FlagSourceArgs
+)
+
+// String returns the human-readable name of the flag source.
+func (s FlagSource) String() string {
+	switch s {
+	case FlagSourceConfig:
+		return "config"
+	case FlagSourceEnv:
+		return "environment"
+	case FlagSourceArgs:
+		return "command-line"
+	default:
+		return "default"
+	}
+}
+
+// flagOptions holds orpheus-level metadata for a single flag that flash-flags does not track.
+type flagOptions struct {
+	required           bool
+	sensitive          bool
+	envVar             string
+	shorthand          string   // shorthand translated by orpheus for types flash-flags cannot abbreviate
+	kind               string   // orpheus type layered on the flash-flags storage type, or ""
+	choices            []string // allowed values of enum flags
+	hidden             bool
+	deprecated         bool
+	deprecationMessage string
+	group              string // help section of the flag
+	completion         CompletionHandler
+}
+
+// flagRuleKind identifies the kind of relationship enforced between flags.
+type flagRuleKind int
+
+const (
+	// ruleMutuallyExclusive allows at most one flag of the group to be set
+	ruleMutuallyExclusive flagRuleKind = iota
+	// ruleOneRequired requires at least one flag of the group to be set
+	ruleOneRequired
+	// ruleRequires requires all dependencies to be set when the flag is set
+	ruleRequires
+)
+
+// flagRule is a relationship constraint between flags.
+type flagRule struct {
+	kind  flagRuleKind
+	flag  string   // the dependent flag (ruleRequires only)
+	flags []string // the group members or dependencies
+}
+
+// flagMeta tracks orpheus-level metadata and constraints for a flash-flags FlagSet.
+type flagMeta struct {
+	options map[string]*flagOptions
+	rules   []flagRule
+}
+
+// newFlagMeta creates an empty flag metadata registry.
+func newFlagMeta() *flagMeta {
+	return &flagMeta{options: make(map[string]*flagOptions)}
+}
+
+// option returns the options for a flag, creating them if needed.
+func (m *flagMeta) option(name string) *flagOptions {
+	opts, exists := m.options[name]
+	if !exists {
+		opts = &flagOptions{}
+		m.options[name] = opts
+	}
+	return opts
+}
+
+// lookup returns the options for a flag, or nil if none were recorded.
+func (m *flagMeta) lookup(name string) *flagOptions {
+	if m == nil {
+		return nil
+	}
+	return m.options[name]
+}
+
+// addRule records a relationship constraint.
+func (m *flagMeta) addRule(rule flagRule) {
+	m.rules = append(m.rules, rule)
+}
+
+// validate checks required flags and relationship constraints against a parsed flag set.
+// A flag counts as set when it was provided by any source (argv, environment, config).
+func (m *flagMeta) validate(command string, fs *flashflags.FlagSet) error {
+	if m == nil || fs == nil {
+		return nil
+	}
+
+	if err := m.checkDeclared(command, fs); err != nil {
+		return err
+	}
+
+	if err := m.validateRequired(command, fs); err != nil {
+		return err
+	}
+
+	for _, rule := range m.rules {
+		if err := rule.validate(command, fs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkDeclared ensures every flag referenced by a constraint exists in the flag set.
+func (m *flagMeta) checkDeclared(command string, fs *flashflags.FlagSet) error {
+	for name, opts := range m.options {
+		if opts.required && fs.Lookup(name) == nil {
+			return InternalError(fmt.Sprintf("command '%s': required flag --%s is not defined", command, name))
+		}
+	}
+
+	for _, rule := range m.rules {
+		names := rule.flags
+		if rule.kind == ruleRequires {
+			names = append([]string{rule.flag}, rule.flags...)
+		}
+		for _, name := range names {
+			if fs.Lookup(name) == nil {
+				return InternalError(fmt.Sprintf("command '%s': flag constraint references undefined flag --%s", command, name))
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateRequired reports all required flags that were not provided.
+func (m *flagMeta) validateRequired(command string, fs *flashflags.FlagSet) error {
+	var missing []string
+	fs.VisitAll(func(flag *flashflags.Flag) {
+		if opts := m.options[flag.Name()]; opts != nil && opts.required && !flag.Changed() {
+			missing = append(missing, flag.Name())
+		}
+	})
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	sort.Strings(missing)
+	noun := "flag"
+	if len(missing) > 1 {
+		noun = "flags"
+	}
+	return ValidationError(command, fmt.Sprintf("required %s %s not provided", noun, formatFlagList(missing, ", "))).
+		WithUserMessage(fmt.Sprintf("Missing required %s: %s", noun, formatFlagList(missing, ", "))).
+		WithContext("flags", missing)
+}
+
+// validate checks a single relationship constraint.
+func (r flagRule) validate(command string, fs *flashflags.FlagSet) error {
+	set := changedFlags(fs, r.flags)
+
+	switch r.kind {
+	case ruleMutuallyExclusive:
+		if len(set) > 1 {
+			return ValidationError(command, fmt.Sprintf("flags %s are mutually exclusive", formatFlagList(set, " and "))).
+				WithUserMessage(fmt.Sprintf("Only one of %s can be used at a time", formatFlagList(r.flags, ", "))).
+				WithContext("flags", set)
+		}
+	case ruleOneRequired:
+		if len(set) == 0 {
+			return ValidationError(command, fmt.Sprintf("one of the flags %s is required", formatFlagList(r.flags, ", "))).
+				WithUserMessage(fmt.Sprintf("Please provide one of %s", formatFlagList(r.flags, ", "))).
+				WithContext("flags", r.flags)
+		}
+	case ruleRequires:
+		if !fs.Changed(r.flag) {
+			return nil
+		}
+		var missing []string
+		for _, dep := range r.flags {
+			if !fs.Changed(dep) {
+				missing = append(missing, dep)
+			}
+		}
+		if len(missing) > 0 {
+			return ValidationError(command, fmt.Sprintf("flag --%s requires %s", r.flag, formatFlagList(missing, " and "))).
+				WithUserMessage(fmt.Sprintf("Flag --%s can only be used together with %s", r.flag, formatFlagList(missing, ", "))).
+				WithContext("flag", r.flag).
+				WithContext("flags", missing)
+		}
+	}
+
+	return nil
+}
+
+// describe returns a human-readable description of the constraint for help output.
+func (r flagRule) describe() string {
+	switch r.kind {
+	case ruleMutuallyExclusive:
+		return formatFlagList(r.flags, ", ") + " are mutually exclusive"
+	case ruleOneRequired:
+		return "one of " + formatFlagList(r.flags, ", ") + " is required"
+	case ruleRequires:
+		return "--" + r.flag + " requires " + formatFlagList(r.flags, ", ")
+	default:
+		return ""
+	}
+}
+
+// changedFlags returns the subset of names whose flags were set.
+func changedFlags(fs *flashflags.FlagSet, names []string) []string {
+	var set []string
+	for _, name := range names {
+		if fs.Changed(name) {
+			set = append(set, name)
+		}
+	}
+	return set
+}
+
+// formatFlagList renders flag names as "--a, --b" using the given separator.
+func formatFlagList(names []string, sep string) string {
+	decorated := make([]string, len(names))
+	for i, name := range names {
+		decorated[i] = "--" + name
+	}
+	return strings.Join(decorated, sep)
+}
+
+// MarkFlagRequired marks command flags as required.
+// Required flags must be provided or the command fails with a ValidationError.
+func (c *Command) MarkFlagRequired(names ...string) *Command {
+	for _, name := range names {
+		c.flagMeta.option(name).required = true
+	}
+	return c
+}
+
+// MarkFlagSensitive marks command flags whose values must be redacted in audit logs.
+// Flags named like secrets (password, token, secret, ...) are redacted automatically.
+func (c *Command) MarkFlagSensitive(names ...string) *Command {
+	for _, name := range names {
+		c.flagMeta.option(name).sensitive = true
+	}
+	return c
+}
+
+// MarkFlagsMutuallyExclusive declares that at most one of the given flags may be set.
+func (c *Command) MarkFlagsMutuallyExclusive(names ...string) *Command {
+	c.flagMeta.addRule(flagRule{kind: ruleMutuallyExclusive, flags: names})
+	return c
+}
+
+// MarkFlagsOneRequired declares that at least one of the given flags must be set.
+func (c *Command) MarkFlagsOneRequired(names ...string) *Command {
+	c.flagMeta.addRule(flagRule{kind: ruleOneRequired, flags: names})
+	return c
+}
+
+// MarkFlagRequires declares that when name is set, all dependencies must be set too.
+func (c *Command) MarkFlagRequires(name string, dependencies ...string) *Command {
+	c.flagMeta.addRule(flagRule{kind: ruleRequires, flag: name, flags: dependencies})
+	return c
+}
+
+// MarkGlobalFlagRequired marks global flags as required.
+// Required global flags are enforced whenever a command is executed.
+func (app *App) MarkGlobalFlagRequired(names ...string) *App {
+	for _, name := range names {
+		app.globalFlagMeta.option(name).required = true
+	}
+	return app
+}
+
+// MarkGlobalFlagSensitive marks global flags whose values must be redacted in audit logs.
+func (app *App) MarkGlobalFlagSensitive(names ...string) *App {
+	for _, name := range names {
+		app.globalFlagMeta.option(name).sensitive = true
+	}
+	return app
+}
+
+// MarkGlobalFlagsMutuallyExclusive declares that at most one of the given global flags may be set.
+func (app *App) MarkGlobalFlagsMutuallyExclusive(names ...string) *App {
+	app.globalFlagMeta.addRule(flagRule{kind: ruleMutuallyExclusive, flags: names})
+	return app
+}
+
+// MarkGlobalFlagsOneRequired declares that at least one of the given global flags must be set.
+func (app *App) MarkGlobalFlagsOneRequired(names ...string) *App {
+	app.globalFlagMeta.addRule(flagRule{kind: ruleOneRequired, flags: names})
+	return app
+}
+
+// MarkGlobalFlagRequires declares that when the global flag name is set, all dependencies must be set too.
+func (app *App) MarkGlobalFlagRequires(name string, dependencies ...string) *App {
+	app.globalFlagMeta.addRule(flagRule{kind: ruleRequires, flag: name, flags: dependencies})
+	return app
+}
@@ -0,0 +1,188 @@
+// flags_test.go: tests for flag metadata and constraints in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/agilira/orpheus/pkg/orpheus"
+)
+
+// requireValidationError asserts that err is an orpheus validation error containing substr.
+func requireValidationError(t *testing.T, err error, substr string) {
+	t.Helper()
+
+	var orpheusErr *orpheus.Error
+	if !errors.As(err, &orpheusErr) {
+		t.Fatalf("expected *orpheus.Error, got %T: %v", err, err)
+	}
+	if !orpheusErr.IsValidationError() {
+		t.Errorf("expected validation error, got code %s", orpheusErr.ErrorCode())
+	}
+	if !strings.Contains(err.Error(), substr) {
+		t.Errorf("expected error to contain %q, got: %v", substr, err)
+	}
+}
+
+func newConstraintApp(configure func(cmd *orpheus.Command)) *orpheus.App {
+	app := orpheus.New("testapp")
+	cmd := orpheus.NewCommand("deploy", "Deploy application").
+		AddFlag("env", "e", "", "Target environment").
+		AddBoolFlag("json", "", false, "JSON output").
+		AddBoolFlag("yaml", "", false, "YAML output").
+		AddFlag("file", "", "", "Manifest file").
+		AddFlag("url", "", "", "Manifest URL").
+		AddFlag("tls-cert", "", "", "TLS certificate").
+		AddFlag("tls-key", "", "", "TLS key").
+		SetHandler(func(ctx *orpheus.Context) error { return nil })
+	configure(cmd)
+	app.AddCommand(cmd)
+	return app
+}
+
+func TestRequiredFlag(t *testing.T) {
+	app := newConstraintApp(func(cmd *orpheus.Command) {
+		cmd.MarkFlagRequired("env")
+	})
+
+	err := app.Run([]string{"deploy"})
+	requireValidationError(t, err, "required flag --env not provided")
+
+	if err := app.Run([]string{"deploy", "--env", "prod"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Values must not leak between runs
+	err = app.Run([]string{"deploy"})
+	requireValidationError(t, err, "--env")
+}
+
+func TestRequiredFlagsListsAllMissing(t *testing.T) {
+	app := newConstraintApp(func(cmd *orpheus.Command) {
+		cmd.MarkFlagRequired("file", "env")
+	})
+
+	err := app.Run([]string{"deploy"})
+	requireValidationError(t, err, "required flags --env, --file not provided")
+}
+
+func TestMutuallyExclusiveFlags(t *testing.T) {
+	app := newConstraintApp(func(cmd *orpheus.Command) {
+		cmd.MarkFlagsMutuallyExclusive("json", "yaml")
+	})
+
+	err := app.Run([]string{"deploy", "--json", "--yaml"})
+	requireValidationError(t, err, "flags --json and --yaml are mutually exclusive")
+
+	if err := app.Run([]string{"deploy", "--json"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestOneRequiredFlags(t *testing.T) {
+	app := newConstraintApp(func(cmd *orpheus.Command) {
+		cmd.MarkFlagsOneRequired("file", "url")
+	})
+
+	err := app.Run([]string{"deploy"})
+	requireValidationError(t, err, "one of the flags --file, --url is required")
+
+	if err := app.Run([]string{"deploy", "--url", "https://example.com"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestFlagRequires(t *testing.T) {
+	app := newConstraintApp(func(cmd *orpheus.Command) {
+		cmd.MarkFlagRequires("tls-cert", "tls-key")
+	})
+
+	err := app.Run([]string{"deploy", "--tls-cert", "cert.pem"})
+	requireValidationError(t, err, "flag --tls-cert requires --tls-key")
+
+	if err := app.Run([]string{"deploy", "--tls-cert", "cert.pem", "--tls-key", "key.pem"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := app.Run([]string{"deploy"}); err != nil {
+		t.Errorf("unexpected error when dependent flag is unset: %v", err)
+	}
+}
+
+func TestConstraintOnUndefinedFlag(t *testing.T) {
+	app := newConstraintApp(func(cmd *orpheus.Command) {
+		cmd.MarkFlagsMutuallyExclusive("json", "xml")
+	})
+
+	err := app.Run([]string{"deploy"})
+	var orpheusErr *orpheus.Error
+	if !errors.As(err, &orpheusErr) || orpheusErr.ErrorCode() != orpheus.ErrCodeInternal {
+		t.Errorf("expected internal error for undefined flag, got: %v", err)
+	}
+}
+
+func TestGlobalFlagConstraints(t *testing.T) {
+	app := orpheus.New("testapp").
+		AddGlobalFlag("token", "", "", "API token").
+		AddGlobalFlag("token-file", "", "", "API token file").
+		MarkGlobalFlagsOneRequired("token", "token-file").
+		MarkGlobalFlagsMutuallyExclusive("token", "token-file")
+	app.Command("status", "Show status", func(ctx *orpheus.Context) error { return nil })
+
+	err := app.Run([]string{"status"})
+	requireValidationError(t, err, "one of the flags --token, --token-file is required")
+
+	err = app.Run([]string{"--token", "abc", "--token-file", "t.txt", "status"})
+	requireValidationError(t, err, "mutually exclusive")
+
+	if err := app.Run([]string{"--token", "abc", "status"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Help must remain reachable without satisfying constraints
+	if err := app.Run([]string{"help"}); err != nil {
+		t.Errorf("help should not enforce global constraints: %v", err)
+	}
+}
+
+func TestRequiredGlobalFlag(t *testing.T) {
+	app := orpheus.New("testapp").
+		AddGlobalFlag("region", "r", "", "Cloud region").
+		MarkGlobalFlagRequired("region")
+	app.Command("status", "Show status", func(ctx *orpheus.Context) error { return nil })
+
+	err := app.Run([]string{"status"})
+	requireValidationError(t, err, "required flag --region not provided")
+
+	if err := app.Run([]string{"-r", "eu-west-1", "status"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestFlagConstraintsInHelp(t *testing.T) {
+	app := newConstraintApp(func(cmd *orpheus.Command) {
+		cmd.MarkFlagRequired("env").
+			MarkFlagsMutuallyExclusive("json", "yaml").
+			MarkFlagsOneRequired("file", "url").
+			MarkFlagRequires("tls-cert", "tls-key")
+	})
+
+	help := app.GetHelpGenerator().GenerateCommandHelp(app.GetCommands()["deploy"])
+	for _, expected := range []string{
+		"Target environment (default: ) (required)",
+		"Flag Constraints:",
+		"--json, --yaml are mutually exclusive",
+		"one of --file, --url is required",
+		"--tls-cert requires --tls-key",
+	} {
+		if !strings.Contains(help, expected) {
+			t.Errorf("help should contain %q, got:\n%s", expected, help)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// groups.go: command and flag groups for sectioned help output in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+import (
+	"sort"
+)
+
+// additionalCommandsTitle is the section title of ungrouped commands when other commands are grouped.
+const additionalCommandsTitle = "Additional Commands"
+
+// SetGroup places the command in a named section of the app or parent command help
+// (e.g. "Management Commands"). Ungrouped commands are listed under "Additional Commands".
+func (c *Command) SetGroup(group string) *Command {
+	c.group = group
+	return c
+}
+
+// Group returns the help group of the command, or "" if it is ungrouped.
+func (c *Command) Group() string {
+	return c.group
+}
+
+// SetCommandGroups sets the order of command groups in help output. Groups that
+// are not listed follow in alphabetical order.
+func (app *App) SetCommandGroups(groups ...string) *App {
+	app.commandGroups = groups
+	return app
+}
+
+// SetFlagGroup places command or persistent flags in a named section of the command
+// help. Sections appear after the "Flags" section in the order they were first used.
+func (c *Command) SetFlagGroup(group string, names ...string) *Command {
+	for _, name := range names {
+		meta := c.flagMeta
+		if c.persistent != nil && c.persistent.Lookup(name) != nil {
+			meta = c.persistentMeta
+		}
+		meta.option(name).group = group
+	}
+
+	for _, existing := range c.flagGroups {
+		if existing == group {
+			return c
+		}
+	}
+	c.flagGroups = append(c.flagGroups, group)
+	return c
+}
+
+// commandSection is a titled list of commands in help output.
+type commandSection struct {
+	title    string
+	commands []*Command
+}
+
+// commandSections splits the visible commands into help sections. Without groups
+// every command is listed under title; otherwise groups come first, in the
+// configured order, followed by the ungrouped commands.
+func (app *App) commandSections(commands map[string]*Command, title string) []commandSection {
+	visible := visibleCommands(commands)
+
+	byGroup := make(map[string][]*Command)
+	var ungrouped []*Command
+	for _, cmd := range visible {
+		if cmd.group == "" {
+			ungrouped = append(ungrouped, cmd)
+			continue
+		}
+		byGroup[cmd.group] = append(byGroup[cmd.group], cmd)
+	}
+	if len(byGroup) == 0 {
+		return []commandSection{{title: title, commands: visible}}
+	}
+
+	var sections []commandSection
+	for _, group := range app.groupOrder(byGroup) {
+		sections = append(sections, commandSection{title: group, commands: byGroup[group]})
+	}
+	return append(sections, commandSection{title: additionalCommandsTitle, commands: ungrouped})
+}
+
+// groupOrder returns the groups in their configured order, then the remaining
+// groups alphabetically.
+func (app *App) groupOrder(byGroup map[string][]*Command) []string {
+	var order []string
+	seen := make(map[string]bool)
+	if app != nil {
+		for _, group := range app.commandGroups {
+			if len(byGroup[group]) > 0 && !seen[group] {
+				order = append(order, group)
+				seen[group] = true
+			}
+		}
+	}
+
+	var rest []string
+	for group := range byGroup {
+		if !seen[group] {
+			rest = append(rest, group)
+		}
+	}
+	sort.Strings(rest)
+	return append(order, rest...)
+}
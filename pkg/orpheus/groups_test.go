@@ -0,0 +1,105 @@
+// groups_test.go: tests for command and flag groups in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agilira/orpheus/pkg/orpheus"
+)
+
+func noop(*orpheus.Context) error { return nil }
+
+func TestGroupedAppHelp(t *testing.T) {
+	app := orpheus.New("testapp").SetCommandGroups("Management Commands", "Core Commands")
+	app.AddCommand(orpheus.NewCommand("run", "Run a container").SetGroup("Core Commands").SetHandler(noop))
+	app.AddCommand(orpheus.NewCommand("build", "Build an image").SetGroup("Core Commands").SetHandler(noop))
+	app.AddCommand(orpheus.NewCommand("image", "Manage images").SetGroup("Management Commands").SetHandler(noop))
+	app.AddCommand(orpheus.NewCommand("volume", "Manage volumes").SetGroup("Management Commands").SetHandler(noop))
+	app.AddCommand(orpheus.NewCommand("debug", "Debug tools").SetGroup("Diagnostics").SetHandler(noop))
+	app.Command("version", "Show version", noop)
+
+	help := app.GenerateHelp()
+	want := `Management Commands:
+  image    Manage images
+  volume   Manage volumes
+
+Core Commands:
+  build    Build an image
+  run      Run a container
+
+Diagnostics:
+  debug    Debug tools
+
+Additional Commands:
+  version  Show version
+  help     Show help for commands
+`
+	if !strings.Contains(help, want) {
+		t.Errorf("unexpected grouped help:\n%s", help)
+	}
+	if strings.Contains(help, "Available Commands:") {
+		t.Errorf("grouped help should not have a flat command list:\n%s", help)
+	}
+}
+
+func TestUngroupedAppHelpUnchanged(t *testing.T) {
+	app := orpheus.New("testapp")
+	app.Command("ls", "List", noop)
+
+	want := "Available Commands:\n  ls    List\n  help  Show help for commands\n"
+	if help := app.GenerateHelp(); !strings.Contains(help, want) {
+		t.Errorf("unexpected help:\n%s", help)
+	}
+}
+
+func TestGroupedSubcommandHelp(t *testing.T) {
+	app := orpheus.New("testapp")
+	remote := orpheus.NewCommand("remote", "Manage remotes")
+	remote.Subcommand("add", "Add a remote", noop).SetGroup("Setup")
+	remote.Subcommand("remove", "Remove a remote", noop).SetGroup("Setup")
+	remote.Subcommand("show", "Show a remote", noop)
+	app.AddCommand(remote)
+
+	help := app.GetHelpGenerator().GenerateCommandHelp(remote)
+	setup := strings.Index(help, "Setup:\n")
+	additional := strings.Index(help, "Additional Commands:\n")
+	if setup < 0 || additional < setup || strings.Contains(help, "Available Subcommands:") {
+		t.Fatalf("unexpected subcommand sections:\n%s", help)
+	}
+	if !strings.Contains(help[setup:additional], "remove") || !strings.Contains(help[additional:], "show") {
+		t.Errorf("subcommands in wrong sections:\n%s", help)
+	}
+}
+
+func TestFlagGroupsHelp(t *testing.T) {
+	app := orpheus.New("testapp")
+	cmd := orpheus.NewCommand("serve", "Serve").
+		AddFlag("addr", "", ":8080", "Listen address").
+		AddFlag("tls-cert", "", "", "TLS certificate").
+		AddFlag("tls-key", "", "", "TLS key").
+		AddPersistentBoolFlag("verbose", "", false, "Verbose output").
+		SetFlagGroup("TLS Flags", "tls-cert", "tls-key").
+		SetFlagGroup("Output Flags", "verbose").
+		SetHandler(noop)
+	app.AddCommand(cmd)
+
+	help := app.GetHelpGenerator().GenerateCommandHelp(cmd)
+	flags := strings.Index(help, "Flags:\n")
+	tls := strings.Index(help, "TLS Flags:\n")
+	output := strings.Index(help, "Output Flags:\n")
+	if flags < 0 || tls < flags || output < tls {
+		t.Fatalf("unexpected flag sections:\n%s", help)
+	}
+	if !strings.Contains(help[flags:tls], "--addr") || strings.Contains(help[flags:tls], "--tls-key") {
+		t.Errorf("ungrouped section wrong:\n%s", help)
+	}
+	if !strings.Contains(help[tls:output], "--tls-cert") || !strings.Contains(help[output:], "--verbose") {
+		t.Errorf("grouped sections wrong:\n%s", help)
+	}
+}
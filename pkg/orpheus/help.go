@@ -26,236 +26,224 @@ func NewHelpGenerator(app *App) *HelpGenerator {
 
 // GenerateCommandHelp generates detailed help for a specific command.
 func (h *HelpGenerator) GenerateCommandHelp(cmd *Command) string {
-	var sb strings.Builder
-
-	// Build help sections
-	h.addCommandUsage(&sb, cmd)
-	h.addCommandDescription(&sb, cmd)
-	h.addSubcommands(&sb, cmd)
-	h.addExamples(&sb, cmd)
-	h.addCommandFlags(&sb, cmd)
-	h.addGlobalFlags(&sb)
-
-	return sb.String()
+	return h.app.renderHelp("help", h.commandHelpData(cmd))
 }
 
-// addCommandUsage adds the usage line to the help text
-func (h *HelpGenerator) addCommandUsage(sb *strings.Builder, cmd *Command) {
-	usage := cmd.Usage()
-	if cmd.HasSubcommands() {
-		usage = cmd.name + " <subcommand> [flags]"
-	}
-	sb.WriteString(fmt.Sprintf("Usage: %s %s\n\n", h.app.name, usage))
+// GenerateAppHelp generates the main application help.
+func (h *HelpGenerator) GenerateAppHelp() string {
+	return h.app.renderHelp("help", h.appHelpData())
 }
 
-// addCommandDescription adds the command description to the help text
-func (h *HelpGenerator) addCommandDescription(sb *strings.Builder, cmd *Command) {
-	if cmd.Description() != "" {
-		sb.WriteString(fmt.Sprintf("%s\n\n", cmd.Description()))
-	}
-
-	// Long description (if available)
-	if cmd.longDescription != "" {
-		sb.WriteString(fmt.Sprintf("%s\n\n", cmd.longDescription))
+// GenerateUsage renders the usage template for a command, or for the application when cmd is nil.
+func (h *HelpGenerator) GenerateUsage(cmd *Command) string {
+	if cmd == nil {
+		return h.app.renderHelp("usage", h.appHelpData())
 	}
+	return h.app.renderHelp("usage", h.commandHelpData(cmd))
 }
 
-// addSubcommands adds the subcommands section to the help text
-func (h *HelpGenerator) addSubcommands(sb *strings.Builder, cmd *Command) {
-	if !cmd.HasSubcommands() {
-		return
-	}
-
-	sb.WriteString("Available Subcommands:\n")
-	subcommands := cmd.GetSubcommands()
-	names := h.sortSubcommandNames(subcommands)
+// appHelpData builds the template data of the application help.
+func (h *HelpGenerator) appHelpData() *HelpData {
+	data := h.baseHelpData()
+	data.Usage = h.app.name + " [command] [flags]"
 
-	for _, name := range names {
-		subcmd := subcommands[name]
-		sb.WriteString(fmt.Sprintf("  %-20s %s\n", name, subcmd.Description()))
-	}
-	sb.WriteString("\n")
-}
-
-// sortSubcommandNames sorts subcommand names for consistent output
-func (h *HelpGenerator) sortSubcommandNames(subcommands map[string]*Command) []string {
-	var names []string
-	for name := range subcommands {
-		names = append(names, name)
+	// Available commands (sorted and grouped, hidden commands omitted)
+	if len(visibleCommands(h.app.commands)) == 0 {
+		return data
 	}
 
-	// Use efficient standard library sorting
-	sort.Strings(names)
-	return names
-}
-
-// addExamples adds the examples section to the help text
-func (h *HelpGenerator) addExamples(sb *strings.Builder, cmd *Command) {
-	if len(cmd.examples) == 0 {
-		return
+	// The built-in help command closes the last (ungrouped) section
+	sections := h.app.commandSections(h.app.commands, "Available Commands")
+	for i, section := range sections {
+		last := i == len(sections)-1
+		if len(section.commands) == 0 && !last {
+			continue
+		}
+		helpSection := newHelpSection(section)
+		if last {
+			helpSection.Commands = append(helpSection.Commands, HelpCommand{Name: "help", Summary: "Show help for commands"})
+		}
+		data.Commands = append(data.Commands, helpSection)
 	}
 
-	sb.WriteString("Examples:\n")
-	for _, example := range cmd.examples {
-		sb.WriteString(fmt.Sprintf("  %s\n", example))
+	// Find longest command name for alignment
+	for _, section := range data.Commands {
+		for _, cmd := range section.Commands {
+			if len(cmd.Name) > data.CommandWidth {
+				data.CommandWidth = len(cmd.Name)
+			}
+		}
 	}
-	sb.WriteString("\n")
+	return data
 }
 
-// addCommandFlags adds the command-specific flags section
-func (h *HelpGenerator) addCommandFlags(sb *strings.Builder, cmd *Command) {
-	if !h.hasCommandFlags(cmd) {
-		return
-	}
-
-	sb.WriteString("Flags:\n")
-	sb.WriteString(h.generateFlagHelp(cmd))
-	sb.WriteString("\n")
-}
+// commandHelpData builds the template data of a command help.
+func (h *HelpGenerator) commandHelpData(cmd *Command) *HelpData {
+	data := h.baseHelpData()
+	command := newHelpCommand(cmd)
+	data.Command = &command
 
-// addGlobalFlags adds the global flags section
-func (h *HelpGenerator) addGlobalFlags(sb *strings.Builder) {
-	sb.WriteString("Global Flags:\n")
-	sb.WriteString(h.generateGlobalFlagHelp())
-}
-
-// GenerateAppHelp generates the main application help.
-func (h *HelpGenerator) GenerateAppHelp() string {
-	var sb strings.Builder
-
-	// Header with description
-	if h.app.description != "" {
-		sb.WriteString(h.app.description + "\n\n")
+	usage := cmd.Usage()
+	if cmd.HasSubcommands() {
+		usage = cmd.name + " <subcommand> [flags]"
 	}
+	data.Usage = h.app.name + " " + usage
 
-	sb.WriteString(fmt.Sprintf("Usage: %s [command] [flags]\n\n", h.app.name))
-
-	// Available commands (sorted)
-	if len(h.app.commands) > 0 {
-		sb.WriteString("Available Commands:\n")
-
-		// Sort commands by name
-		var names []string
-		for name := range h.app.commands {
-			names = append(names, name)
-		}
-		sort.Strings(names)
-
-		// Find longest command name for alignment
-		maxLen := 0
-		for _, name := range names {
-			if len(name) > maxLen {
-				maxLen = len(name)
-			}
+	for _, section := range h.app.commandSections(cmd.subcommands, "Available Subcommands") {
+		if len(section.commands) > 0 {
+			data.Commands = append(data.Commands, newHelpSection(section))
 		}
+	}
 
-		// Add commands with descriptions
-		for _, name := range names {
-			cmd := h.app.commands[name]
-			padding := strings.Repeat(" ", maxLen-len(name)+2)
-			sb.WriteString(fmt.Sprintf("  %s%s%s\n", name, padding, cmd.Description()))
+	// Command-specific and persistent flags, ungrouped first
+	data.Flags = commandHelpFlags(cmd, "")
+	for _, group := range cmd.flagGroups {
+		if flags := commandHelpFlags(cmd, group); len(flags) > 0 {
+			data.FlagGroups = append(data.FlagGroups, HelpFlagGroup{Title: group, Flags: flags})
 		}
-
-		// Add built-in help command
-		padding := strings.Repeat(" ", maxLen-4+2)
-		sb.WriteString(fmt.Sprintf("  help%sShow help for commands\n", padding))
-		sb.WriteString("\n")
 	}
+	data.FlagConstraints = describeRules(cmd.flagMeta)
 
-	// Global flags
-	sb.WriteString("Global Flags:\n")
-	sb.WriteString(h.generateGlobalFlagHelp())
-	sb.WriteString("\n")
-
-	// Footer
-	sb.WriteString(fmt.Sprintf("Use \"%s help [command]\" for more information about a command.\n", h.app.name))
-
-	return sb.String()
+	// Persistent flags inherited from parent commands
+	for parent := cmd.parent; parent != nil; parent = parent.parent {
+		data.InheritedFlags = append(data.InheritedFlags, helpFlags(parent.persistent, parent.persistentMeta, nil)...)
+	}
+	return data
 }
 
-// generateGlobalFlagHelp generates help text for global flags.
-func (h *HelpGenerator) generateGlobalFlagHelp() string {
-	var sb strings.Builder
-
-	// Built-in flags
-	sb.WriteString("  -h, --help      Show help\n")
-	if h.app.version != "" {
-		sb.WriteString("  -v, --version   Show version\n")
+// baseHelpData builds the template data shared by the application and command help.
+func (h *HelpGenerator) baseHelpData() *HelpData {
+	return &HelpData{
+		App: HelpApp{
+			Name:        h.app.name,
+			Description: h.app.description,
+			Version:     h.app.version,
+		},
+		GlobalFlags:           helpFlags(h.app.globalFlags, h.app.globalFlagMeta, nil),
+		GlobalFlagConstraints: describeRules(h.app.globalFlagMeta),
 	}
+}
 
-	// Custom global flags from flash-flags
-	if h.app.globalFlags != nil {
-		h.app.globalFlags.VisitAll(func(flag *flashflags.Flag) {
-			sb.WriteString(h.formatFlagHelp(flag))
-		})
+// newHelpSection converts a command section into template data.
+func newHelpSection(section commandSection) HelpSection {
+	helpSection := HelpSection{Title: section.title}
+	for _, cmd := range section.commands {
+		helpSection.Commands = append(helpSection.Commands, newHelpCommand(cmd))
 	}
-
-	return sb.String()
+	return helpSection
 }
 
-// generateFlagHelp generates help text for command-specific flags.
-func (h *HelpGenerator) generateFlagHelp(cmd *Command) string {
-	var sb strings.Builder
-
-	// Command-specific flags from flash-flags
-	if cmd.Flags() != nil {
-		cmd.Flags().VisitAll(func(flag *flashflags.Flag) {
-			sb.WriteString(h.formatFlagHelp(flag))
+// newHelpCommand converts a command into template data.
+func newHelpCommand(cmd *Command) HelpCommand {
+	command := HelpCommand{
+		Name:            cmd.name,
+		FullName:        cmd.FullName(),
+		Aliases:         cmd.aliases,
+		Description:     cmd.Description(),
+		Summary:         commandSummary(cmd),
+		LongDescription: cmd.longDescription,
+		Group:           cmd.group,
+		Deprecated:      cmd.deprecated,
+		Replacement:     cmd.replacement,
+		Examples:        cmd.examples,
+	}
+	for _, spec := range cmd.args {
+		command.Args = append(command.Args, HelpArg{
+			Name:        spec.Name,
+			Usage:       spec.usageName(),
+			Description: spec.Description,
+			Type:        spec.Type.String(),
+			Required:    spec.Required,
+			Variadic:    spec.Variadic,
 		})
 	}
+	return command
+}
 
-	// Always show help flag for commands
-	sb.WriteString("  -h, --help      Show help for this command\n")
-
-	return sb.String()
+// commandHelpFlags returns the visible command and persistent flags of a flag group.
+func commandHelpFlags(cmd *Command, group string) []HelpFlag {
+	inGroup := func(opts *flagOptions) bool { return flagGroup(opts) == group }
+	flags := helpFlags(cmd.flags, cmd.flagMeta, inGroup)
+	return append(flags, helpFlags(cmd.persistent, cmd.persistentMeta, inGroup)...)
 }
 
-// hasCommandFlags checks if a command has any flags defined.
-func (h *HelpGenerator) hasCommandFlags(cmd *Command) bool {
-	if cmd.Flags() == nil {
-		return false
+// helpFlags converts the visible flags of a flag set, sorted by name, into template
+// data. The optional filter selects flags by their options.
+func helpFlags(fs *flashflags.FlagSet, meta *flagMeta, filter func(*flagOptions) bool) []HelpFlag {
+	if fs == nil {
+		return nil
 	}
 
-	hasFlags := false
-	cmd.Flags().VisitAll(func(flag *flashflags.Flag) {
-		hasFlags = true
+	var flags []HelpFlag
+	fs.VisitAll(func(flag *flashflags.Flag) {
+		opts := meta.lookup(flag.Name())
+		if isHiddenFlag(opts) || (filter != nil && !filter(opts)) {
+			return
+		}
+		flags = append(flags, newHelpFlag(flag, opts))
 	})
-	return hasFlags
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags
 }
 
-// formatFlagHelp formats a flash-flags Flag for help output.
-func (h *HelpGenerator) formatFlagHelp(flag *flashflags.Flag) string {
-	var line strings.Builder
-
-	// Build flag name with short key
-	line.WriteString("  ")
-	// Note: flash-flags doesn't expose shortKey directly, so we'll show long form
-	line.WriteString("--")
-	line.WriteString(flag.Name())
+// newHelpFlag converts a flash-flags Flag into template data.
+// The options carry orpheus-level metadata and may be nil.
+func newHelpFlag(flag *flashflags.Flag, opts *flagOptions) HelpFlag {
+	helpFlag := HelpFlag{
+		Name:        flag.Name(),
+		Shorthand:   flag.ShortKey(),
+		Type:        flagType(flag, opts),
+		Syntax:      "--" + flag.Name(),
+		Description: flag.Usage(),
+	}
 
-	// Add type info for non-bool flags
+	// Add type info and default value for non-bool flags
 	if flag.Type() != "bool" {
-		line.WriteString(" ")
-		line.WriteString(strings.ToUpper(flag.Type()))
+		helpFlag.Syntax += " " + strings.ToUpper(helpFlag.Type)
+		if flag.Value() != nil {
+			helpFlag.Default = fmt.Sprintf("%v", flag.Value())
+			helpFlag.HasDefault = true
+		}
 	}
 
-	// Pad to align descriptions
-	for line.Len() < 30 {
-		line.WriteString(" ")
+	if opts != nil {
+		if opts.shorthand != "" {
+			helpFlag.Shorthand = opts.shorthand
+		}
+		helpFlag.Choices = opts.choices
+		helpFlag.Required = opts.required
+		helpFlag.Deprecated = opts.deprecated
 	}
+	return helpFlag
+}
 
-	// Add description
-	line.WriteString(flag.Usage())
+// describeRules returns the descriptions of the flag relationship constraints.
+func describeRules(meta *flagMeta) []string {
+	if meta == nil {
+		return nil
+	}
 
-	// Add default value for non-bool flags
-	if flag.Type() != "bool" && flag.Value() != nil {
-		line.WriteString(" (default: ")
-		line.WriteString(fmt.Sprintf("%v", flag.Value()))
-		line.WriteString(")")
+	var rules []string
+	for _, rule := range meta.rules {
+		rules = append(rules, rule.describe())
 	}
+	return rules
+}
 
-	line.WriteString("\n")
-	return line.String()
+// flagGroup returns the help group recorded in the flag options, or "".
+func flagGroup(opts *flagOptions) string {
+	if opts == nil {
+		return ""
+	}
+	return opts.group
+}
+
+// commandSummary returns the description shown in command listings.
+func commandSummary(cmd *Command) string {
+	if cmd.deprecated {
+		return cmd.Description() + " (deprecated)"
+	}
+	return cmd.Description()
 }
 
 // SetLongDescription sets a detailed description for the command.
@@ -0,0 +1,354 @@
+// help_template.go: template-based help rendering in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+import (
+	"context"
+	"strings"
+	"text/template"
+)
+
+// HelpData is the data model passed to help and usage templates. The same model
+// is used for the application help (Command is nil) and for command help.
+type HelpData struct {
+	// App describes the application
+	App HelpApp
+	// Command is the command the help is for, or nil for the application help
+	Command *HelpCommand
+	// Usage is the usage line without the "Usage: " prefix
+	Usage string
+	// Commands lists the visible commands (or subcommands) in sections. In the
+	// application help the last section ends with the built-in help command.
+	Commands []HelpSection
+	// CommandWidth is the length of the longest command name in Commands
+	CommandWidth int
+	// Flags are the visible ungrouped flags of the command, including its persistent flags
+	Flags []HelpFlag
+	// FlagGroups are the flag sections set with Command.SetFlagGroup
+	FlagGroups []HelpFlagGroup
+	// FlagConstraints describe the flag relationships of the command
+	FlagConstraints []string
+	// InheritedFlags are the persistent flags of the command's ancestors
+	InheritedFlags []HelpFlag
+	// GlobalFlags are the visible custom global flags
+	GlobalFlags []HelpFlag
+	// GlobalFlagConstraints describe the global flag relationships
+	GlobalFlagConstraints []string
+}
+
+// HelpApp describes the application in help templates.
+type HelpApp struct {
+	Name        string
+	Description string
+	Version     string
+}
+
+// HelpCommand describes a command in help templates.
+type HelpCommand struct {
+	Name            string
+	FullName        string // space-separated path from the root command
+	Aliases         []string
+	Description     string
+	Summary         string // description shown in command listings
+	LongDescription string
+	Group           string
+	Deprecated      bool
+	Replacement     string
+	Args            []HelpArg
+	Examples        []string
+}
+
+// HelpArg describes a declared positional argument in help templates.
+type HelpArg struct {
+	Name        string
+	Usage       string // e.g. "<name>", "[port]" or "[files...]"
+	Description string
+	Type        string
+	Required    bool
+	Variadic    bool
+}
+
+// HelpFlag describes a flag in help templates.
+type HelpFlag struct {
+	Name        string
+	Shorthand   string
+	Type        string
+	Syntax      string // e.g. "--output STRING"
+	Description string
+	Default     string
+	HasDefault  bool // false for bool flags
+	Choices     []string
+	Required    bool
+	Deprecated  bool
+}
+
+// Notes returns the flag description followed by its choices, default value and
+// required and deprecated markers, as shown in the help output.
+func (f HelpFlag) Notes() string {
+	notes := f.Description
+	if len(f.Choices) > 0 {
+		notes += " (choices: " + strings.Join(f.Choices, ", ") + ")"
+	}
+	if f.HasDefault {
+		notes += " (default: " + f.Default + ")"
+	}
+	if f.Required {
+		notes += " (required)"
+	}
+	if f.Deprecated {
+		notes += " (deprecated)"
+	}
+	return notes
+}
+
+// Notes returns the argument description followed by its type (unless string)
+// and an optional marker, as shown in the help output.
+func (a HelpArg) Notes() string {
+	notes := a.Description
+	if a.Type != ArgString.String() {
+		notes += " (" + a.Type + ")"
+	}
+	if !a.Required {
+		notes += " (optional)"
+	}
+	return notes
+}
+
+// HelpSection is a titled list of commands in help templates.
+type HelpSection struct {
+	Title    string
+	Commands []HelpCommand
+}
+
+// HelpFlagGroup is a titled list of flags in help templates.
+type HelpFlagGroup struct {
+	Title string
+	Flags []HelpFlag
+}
+
+// defaultUsageTemplate renders the usage line.
+const defaultUsageTemplate = `{{style "heading" "Usage:"}} {{.Usage}}
+`
+
+// defaultHelpTemplate renders the application and command help. It defines the
+// "flag", "globalFlags", "appHelp" and "commandHelp" templates, which custom
+// templates may reuse.
+const defaultHelpTemplate = `{{define "flag"}}  {{style "flag" (pad 28 .Syntax)}}{{hang 30 .Notes}}
+{{end}}
+
+{{- define "globalFlags"}}  -h, --help      Show help
+{{if .App.Version}}  -v, --version   Show version
+{{end}}
+{{- range .GlobalFlags}}{{template "flag" .}}{{end}}
+{{- end}}
+
+{{- define "appHelp"}}
+{{- with .App.Description}}{{fill .}}
+
+{{end}}
+{{- template "usage" .}}
+{{range .Commands}}{{style "heading" (printf "%s:" .Title)}}
+{{range .Commands}}  {{style "command" (pad $.CommandWidth .Name)}}  {{hang (add $.CommandWidth 4) .Summary}}
+{{end}}
+{{end}}{{style "heading" "Global Flags:"}}
+{{template "globalFlags" .}}
+{{with .GlobalFlagConstraints}}{{style "heading" "Global Flag Constraints:"}}
+{{range .}}  {{.}}
+{{end}}
+{{end}}Use "{{.App.Name}} help [command]" for more information about a command.
+{{end}}
+
+{{- define "commandHelp"}}
+{{- template "usage" .}}
+{{with .Command}}{{if .Aliases}}{{style "heading" "Aliases:"}} {{.Name}}, {{join .Aliases ", "}}
+
+{{end}}{{with .Description}}{{fill .}}
+
+{{end}}{{with .LongDescription}}{{fill .}}
+
+{{end}}{{end}}
+{{- range .Commands}}{{style "heading" (printf "%s:" .Title)}}
+{{range .Commands}}  {{style "command" (pad 20 .Name)}} {{hang 23 .Summary}}
+{{end}}
+{{end}}
+{{- with .Command.Args}}{{style "heading" "Arguments:"}}
+{{range .}}  {{pad 20 .Usage}} {{hang 23 .Notes}}
+{{end}}
+{{end}}
+{{- with .Command.Examples}}{{style "heading" "Examples:"}}
+{{range .}}  {{.}}
+{{end}}
+{{end}}
+{{- if or .Flags .FlagGroups}}{{style "heading" "Flags:"}}
+{{range .Flags}}{{template "flag" .}}{{end}}  -h, --help      Show help for this command
+
+{{range .FlagGroups}}{{style "heading" (printf "%s:" .Title)}}
+{{range .Flags}}{{template "flag" .}}{{end}}
+{{end}}{{end}}
+{{- with .FlagConstraints}}{{style "heading" "Flag Constraints:"}}
+{{range .}}  {{.}}
+{{end}}
+{{end}}
+{{- with .InheritedFlags}}{{style "heading" "Inherited Flags:"}}
+{{range .}}{{template "flag" .}}{{end}}
+{{end}}{{style "heading" "Global Flags:"}}
+{{template "globalFlags" .}}
+{{- end}}
+
+{{- if .Command}}{{template "commandHelp" .}}{{else}}{{template "appHelp" .}}{{end}}`
+
+// defaultHelpTemplates is the compiled default template set.
+var defaultHelpTemplates = template.Must(parseHelpTemplates("", ""))
+
+// helpFuncs are the helper functions available to help and usage templates.
+var helpFuncs = template.FuncMap{
+	"pad":    padText,
+	"wrap":   wrapText,
+	"indent": indentText,
+	"join":   strings.Join,
+	"upper":  strings.ToUpper,
+	"add":    func(a, b int) int { return a + b },
+	// Replaced at render time with the terminal width and color settings
+	"style": func(kind, s string) string { return s },
+	"hang":  func(indent int, s string) string { return s },
+	"fill":  func(s string) string { return s },
+}
+
+// SetHelpTemplate sets a text/template source used for the application and command
+// help. The template receives a HelpData value and may use the helper functions
+// pad, wrap, indent, join, upper and add, the terminal-aware style, hang and fill
+// (see SetColorMode and SetHelpWidth), and the templates of the default layout
+// ("usage", "flag", "globalFlags", "appHelp" and "commandHelp"). An invalid
+// template is reported when the application runs.
+func (app *App) SetHelpTemplate(text string) *App {
+	app.helpTemplate = text
+	app.compileHelpTemplates()
+	return app
+}
+
+// SetUsageTemplate sets a text/template source for the usage line, rendered by the
+// "usage" template of the help output. It receives the same HelpData as the help template.
+func (app *App) SetUsageTemplate(text string) *App {
+	app.usageTemplate = text
+	app.compileHelpTemplates()
+	return app
+}
+
+// compileHelpTemplates parses the custom templates, recording a parse error for Run.
+func (app *App) compileHelpTemplates() {
+	tmpl, err := parseHelpTemplates(app.helpTemplate, app.usageTemplate)
+	if err != nil {
+		app.templates = nil
+		app.templateErr = InternalError("help template parsing failed: "+err.Error()).
+			WithContext("operation", "help_template")
+		return
+	}
+	app.templates = tmpl
+	app.templateErr = nil
+}
+
+// parseHelpTemplates builds the template set: the default layout, overridden by
+// the custom help and usage templates when given.
+func parseHelpTemplates(helpText, usageText string) (*template.Template, error) {
+	tmpl, err := template.New("help").Funcs(helpFuncs).Parse(defaultHelpTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tmpl.New("usage").Parse(defaultUsageTemplate); err != nil {
+		return nil, err
+	}
+	if usageText != "" {
+		if _, err := tmpl.New("usage").Parse(usageText); err != nil {
+			return nil, err
+		}
+	}
+	if helpText != "" {
+		// The redefined "help" becomes the root so that Clone keeps it
+		if tmpl, err = tmpl.New("help").Parse(helpText); err != nil {
+			return nil, err
+		}
+	}
+	return tmpl, nil
+}
+
+// renderHelp executes the named template of the application's template set. When a
+// custom template fails, the error is reported as a warning and the default
+// layout is rendered instead.
+func (app *App) renderHelp(name string, data *HelpData) string {
+	tmpl := defaultHelpTemplates
+	if app.templates != nil {
+		tmpl = app.templates
+	}
+
+	funcs := styleFuncs(app.terminalWidth(), app.colorEnabled())
+
+	var sb strings.Builder
+	err := template.Must(tmpl.Clone()).Funcs(funcs).ExecuteTemplate(&sb, name, data)
+	if err == nil {
+		return sb.String()
+	}
+
+	app.warn(context.Background(), "help template failed: "+err.Error())
+	sb.Reset()
+	if err := template.Must(defaultHelpTemplates.Clone()).Funcs(funcs).ExecuteTemplate(&sb, name, data); err != nil {
+		return ""
+	}
+	return sb.String()
+}
+
+// padText pads s with spaces to width. Longer text is returned unchanged.
+func padText(width int, s string) string {
+	if len(s) >= width {
+		return s
+	}
+	return s + strings.Repeat(" ", width-len(s))
+}
+
+// wrapText wraps each line of s longer than width at word boundaries. Words
+// longer than width are kept on their own line.
+func wrapText(width int, s string) string {
+	if width <= 0 {
+		return s
+	}
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if len(line) <= width {
+			continue
+		}
+
+		var wrapped strings.Builder
+		lineLen := 0
+		for _, word := range strings.Fields(line) {
+			switch {
+			case lineLen == 0:
+			case lineLen+1+len(word) > width:
+				wrapped.WriteString("\n")
+				lineLen = 0
+			default:
+				wrapped.WriteString(" ")
+				lineLen++
+			}
+			wrapped.WriteString(word)
+			lineLen += len(word)
+		}
+		lines[i] = wrapped.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// indentText prefixes every non-empty line of s with n spaces.
+func indentText(n int, s string) string {
+	prefix := strings.Repeat(" ", n)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
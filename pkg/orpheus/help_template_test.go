@@ -0,0 +1,155 @@
+// help_template_test.go: tests for template-based help rendering in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/agilira/orpheus/pkg/orpheus"
+)
+
+func newTemplateApp() (*orpheus.App, *orpheus.Command) {
+	app := orpheus.New("testapp").SetDescription("Test application").SetVersion("1.0.0").
+		AddGlobalBoolFlag("debug", "d", false, "Enable debug")
+	cmd := orpheus.NewCommand("deploy", "Deploy the application").
+		AddAlias("dp").
+		AddArg("env", "Target environment").
+		AddExample("testapp deploy prod").
+		AddFlag("image", "i", "latest", "Image tag").
+		AddBoolFlag("wait", "", false, "Wait for rollout").
+		MarkFlagRequired("image").
+		SetHandler(noop)
+	app.AddCommand(cmd)
+	return app, cmd
+}
+
+func TestDefaultHelpTemplateOutput(t *testing.T) {
+	app, cmd := newTemplateApp()
+
+	want := `Test application
+
+Usage: testapp [command] [flags]
+
+Available Commands:
+  deploy  Deploy the application
+  help    Show help for commands
+
+Global Flags:
+  -h, --help      Show help
+  -v, --version   Show version
+  --debug                     Enable debug
+
+Use "testapp help [command]" for more information about a command.
+`
+	if got := app.GenerateHelp(); got != want {
+		t.Errorf("app help:\n%s\nwant:\n%s", got, want)
+	}
+
+	want = `Usage: testapp deploy [flags] <env>
+
+Aliases: deploy, dp
+
+Deploy the application
+
+Arguments:
+  <env>                Target environment
+
+Examples:
+  testapp deploy prod
+
+Flags:
+  --image STRING              Image tag (default: latest) (required)
+  --wait                      Wait for rollout
+  -h, --help      Show help for this command
+
+Global Flags:
+  -h, --help      Show help
+  -v, --version   Show version
+  --debug                     Enable debug
+`
+	if got := app.GetHelpGenerator().GenerateCommandHelp(cmd); got != want {
+		t.Errorf("command help:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestCustomHelpTemplate(t *testing.T) {
+	app, cmd := newTemplateApp()
+	app.SetHelpTemplate(`{{if .Command}}{{.Command.FullName}}: {{.Command.Summary}}
+{{range .Flags}}{{pad 10 .Name}}|{{.Type}}|{{.Default}}
+{{end}}{{else}}{{upper .App.Name}} {{.App.Version}}
+{{range .Commands}}{{range .Commands}}{{.Name}} {{end}}{{end}}
+{{end}}{{template "usage" .}}`)
+
+	want := "TESTAPP 1.0.0\ndeploy help \nUsage: testapp [command] [flags]\n"
+	if got := app.GenerateHelp(); got != want {
+		t.Errorf("app help = %q, want %q", got, want)
+	}
+
+	want = "deploy: Deploy the application\nimage     |string|latest\nwait      |bool|\nUsage: testapp deploy [flags] <env>\n"
+	if got := app.GetHelpGenerator().GenerateCommandHelp(cmd); got != want {
+		t.Errorf("command help = %q, want %q", got, want)
+	}
+}
+
+func TestCustomUsageTemplate(t *testing.T) {
+	app, cmd := newTemplateApp()
+	app.SetUsageTemplate("USAGE\n{{indent 4 .Usage}}\n")
+
+	help := app.GetHelpGenerator().GenerateCommandHelp(cmd)
+	if !strings.HasPrefix(help, "USAGE\n    testapp deploy [flags] <env>\n\nAliases:") {
+		t.Errorf("usage template not applied:\n%s", help)
+	}
+	if usage := app.GetHelpGenerator().GenerateUsage(nil); usage != "USAGE\n    testapp [command] [flags]\n" {
+		t.Errorf("usage = %q", usage)
+	}
+}
+
+func TestHelpTemplateWrap(t *testing.T) {
+	app := orpheus.New("testapp").
+		SetDescription("one two three four five six").
+		SetHelpTemplate(`{{wrap 9 .App.Description}}`)
+
+	if got := app.GenerateHelp(); got != "one two\nthree\nfour five\nsix" {
+		t.Errorf("wrapped = %q", got)
+	}
+}
+
+func TestInvalidHelpTemplate(t *testing.T) {
+	app, _ := newTemplateApp()
+	app.SetHelpTemplate("{{if .Command}")
+
+	err := app.Run([]string{"deploy", "-i", "x", "prod"})
+	var orpheusErr *orpheus.Error
+	if !errors.As(err, &orpheusErr) || orpheusErr.ErrorCode() != orpheus.ErrCodeInternal {
+		t.Fatalf("expected internal error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "help template parsing failed") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// A valid template clears the error
+	app.SetHelpTemplate("{{.App.Name}}")
+	if err := app.Run([]string{"deploy", "-i", "x", "prod"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestHelpTemplateExecutionErrorFallsBack(t *testing.T) {
+	var stderr bytes.Buffer
+	app, _ := newTemplateApp()
+	app.SetErrorOutput(&stderr).SetHelpTemplate(`{{.Missing}}`)
+
+	if help := app.GenerateHelp(); !strings.Contains(help, "Available Commands:") {
+		t.Errorf("expected default help as fallback:\n%s", help)
+	}
+	if !strings.Contains(stderr.String(), "Warning: help template failed") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
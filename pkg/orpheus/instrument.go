@@ -0,0 +1,202 @@
+// instrument.go: automatic tracing, metrics and audit logging of command runs in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+import (
+	"errors"
+	"os"
+	"os/user"
+	"strings"
+	"time"
+
+	flashflags "github.com/agilira/flash-flags"
+)
+
+// Metric names emitted for every command run.
+const (
+	// MetricCommandDuration is the histogram of command durations in seconds, labelled by command
+	MetricCommandDuration = "orpheus_command_duration_seconds"
+	// MetricCommandErrors is the counter of failed commands, labelled by command and error code
+	MetricCommandErrors = "orpheus_command_errors_total"
+)
+
+// redactedValue replaces sensitive flag values in audit logs.
+const redactedValue = "***"
+
+// commandDurationBuckets are the histogram buckets (seconds) for command durations.
+var commandDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// sensitiveFlagPatterns mark flags whose values are redacted even when not explicitly marked sensitive.
+var sensitiveFlagPatterns = []string{"password", "passwd", "secret", "token", "apikey", "api-key", "api_key", "credential", "private-key"}
+
+// instrument runs a command with the tracer, metrics collector and audit logger configured on the app.
+// The span is named after the full command path and records errors and the final status.
+func (c *Command) instrument(ctx *Context, args []string, run func() error) error {
+	app := ctx.App
+	if app == nil || (app.tracer == nil && app.metricsCollector == nil && app.auditLogger == nil) {
+		return run()
+	}
+
+	name := c.FullName()
+	spanCtx := ctx.Context()
+	var span Span
+	if app.tracer != nil {
+		spanCtx, span = app.tracer.StartSpan(spanCtx, name)
+		span.SetAttribute("command", name)
+		ctx.stdCtx = spanCtx
+	}
+
+	start := time.Now()
+	err := run()
+	duration := time.Since(start)
+	code := errorCodeLabel(err)
+
+	if span != nil {
+		if err != nil {
+			span.RecordError(err)
+			span.SetAttribute("error.code", code)
+			span.SetStatus(StatusCodeError, err.Error())
+		} else {
+			span.SetStatus(StatusCodeOK, "")
+		}
+		span.End()
+	}
+
+	if app.metricsCollector != nil {
+		app.metricsCollector.Histogram(MetricCommandDuration, "Duration of command executions in seconds", commandDurationBuckets, "command").
+			Observe(spanCtx, duration.Seconds(), name)
+		if err != nil {
+			app.metricsCollector.Counter(MetricCommandErrors, "Number of failed command executions", "command", "code").
+				Inc(spanCtx, name, code)
+		}
+	}
+
+	if app.auditLogger != nil {
+		status := "success"
+		if err != nil {
+			status = "error"
+		}
+		fields := []Field{
+			StringField("status", status),
+			{Key: "duration_ms", Value: duration.Milliseconds()},
+		}
+		if err != nil {
+			fields = append(fields, StringField("error_code", code))
+		}
+		app.auditLogger.LogCommand(spanCtx, name, c.redactArgs(app, args), currentUser(), fields...)
+	}
+
+	return err
+}
+
+// errorCodeLabel returns the error code used as metric label, or "" for success.
+func errorCodeLabel(err error) string {
+	if err == nil {
+		return ""
+	}
+	var orpheusErr *Error
+	if errors.As(err, &orpheusErr) {
+		return string(orpheusErr.ErrorCode())
+	}
+	return "unknown"
+}
+
+// currentUser returns the name of the user running the application.
+func currentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return os.Getenv("USERNAME")
+}
+
+// redactArgs returns a copy of args where values of sensitive flags are replaced by "***".
+func (c *Command) redactArgs(app *App, args []string) []string {
+	redacted := make([]string, len(args))
+	copy(redacted, args)
+
+	for i := 0; i < len(redacted); i++ {
+		arg := redacted[i]
+		if arg == "--" {
+			break
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			continue
+		}
+
+		name, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		flag, sensitive := c.lookupAuditFlag(app, name)
+		if !sensitive {
+			continue
+		}
+
+		if hasValue {
+			redacted[i] = arg[:strings.Index(arg, "=")+1] + redactedValue
+			continue
+		}
+		takesValue := flag == nil || flag.Type() != "bool"
+		if takesValue && i+1 < len(redacted) && !strings.HasPrefix(redacted[i+1], "-") {
+			redacted[i+1] = redactedValue
+			i++
+		}
+	}
+
+	return redacted
+}
+
+// lookupAuditFlag finds a command, persistent or global flag by name or shorthand and reports whether it is sensitive.
+func (c *Command) lookupAuditFlag(app *App, name string) (*flashflags.Flag, bool) {
+	if flag := findFlag(c.flags, c.flagMeta, name); flag != nil {
+		return flag, isSensitiveFlag(flag.Name(), c.flagMeta.lookup(flag.Name()))
+	}
+	for cmd := c; cmd != nil; cmd = cmd.parent {
+		if flag := findFlag(cmd.persistent, cmd.persistentMeta, name); flag != nil {
+			return flag, isSensitiveFlag(flag.Name(), cmd.persistentMeta.lookup(flag.Name()))
+		}
+	}
+	if flag := findFlag(app.globalFlags, app.globalFlagMeta, name); flag != nil {
+		return flag, isSensitiveFlag(flag.Name(), app.globalFlagMeta.lookup(flag.Name()))
+	}
+	return nil, isSensitiveFlag(name, nil)
+}
+
+// findFlag looks up a flag by long name or shorthand.
+func findFlag(fs *flashflags.FlagSet, meta *flagMeta, name string) *flashflags.Flag {
+	if fs == nil {
+		return nil
+	}
+	if flag := fs.Lookup(name); flag != nil {
+		return flag
+	}
+	if long := meta.longName(name); long != "" {
+		return fs.Lookup(long)
+	}
+
+	var found *flashflags.Flag
+	fs.VisitAll(func(flag *flashflags.Flag) {
+		if flag.ShortKey() != "" && flag.ShortKey() == name {
+			found = flag
+		}
+	})
+	return found
+}
+
+// isSensitiveFlag reports whether the flag was marked sensitive or its name looks like a secret.
+func isSensitiveFlag(name string, opts *flagOptions) bool {
+	if opts != nil && opts.sensitive {
+		return true
+	}
+	lower := strings.ToLower(name)
+	for _, pattern := range sensitiveFlagPatterns {
+		if strings.Contains(lower, pattern) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,211 @@
+// instrument_test.go: tests for automatic command instrumentation in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/agilira/orpheus/pkg/orpheus"
+)
+
+type recordedSpan struct {
+	name       string
+	attributes map[string]interface{}
+	status     orpheus.StatusCode
+	errors     []error
+	ended      bool
+}
+
+func (s *recordedSpan) SetAttribute(key string, value interface{}) { s.attributes[key] = value }
+func (s *recordedSpan) SetStatus(code orpheus.StatusCode, description string) {
+	s.status = code
+}
+func (s *recordedSpan) RecordError(err error, opts ...orpheus.ErrorOption) {
+	s.errors = append(s.errors, err)
+}
+func (s *recordedSpan) End() { s.ended = true }
+
+type recordingTracer struct {
+	spans []*recordedSpan
+}
+
+type spanKey struct{}
+
+func (t *recordingTracer) StartSpan(ctx context.Context, name string, opts ...orpheus.SpanOption) (context.Context, orpheus.Span) {
+	span := &recordedSpan{name: name, attributes: make(map[string]interface{})}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func (t *recordingTracer) SpanFromContext(ctx context.Context) orpheus.Span {
+	if span, ok := ctx.Value(spanKey{}).(*recordedSpan); ok {
+		return span
+	}
+	return nil
+}
+
+// recordingMetrics records observations as "metric{labels}" keys.
+type recordingMetrics struct {
+	observations map[string][]string
+}
+
+type recordingInstrument struct {
+	metrics *recordingMetrics
+	name    string
+}
+
+func (m *recordingMetrics) Counter(name, description string, labels ...string) orpheus.Counter {
+	return &recordingInstrument{metrics: m, name: name}
+}
+func (m *recordingMetrics) Gauge(name, description string, labels ...string) orpheus.Gauge {
+	return &recordingInstrument{metrics: m, name: name}
+}
+func (m *recordingMetrics) Histogram(name, description string, buckets []float64, labels ...string) orpheus.Histogram {
+	return &recordingInstrument{metrics: m, name: name}
+}
+
+func (i *recordingInstrument) record(labels []string) {
+	i.metrics.observations[i.name] = append(i.metrics.observations[i.name], labels...)
+}
+func (i *recordingInstrument) Inc(ctx context.Context, labels ...string) { i.record(labels) }
+func (i *recordingInstrument) Dec(ctx context.Context, labels ...string) { i.record(labels) }
+func (i *recordingInstrument) Add(ctx context.Context, value float64, labels ...string) {
+	i.record(labels)
+}
+func (i *recordingInstrument) Set(ctx context.Context, value float64, labels ...string) {
+	i.record(labels)
+}
+func (i *recordingInstrument) Observe(ctx context.Context, value float64, labels ...string) {
+	i.record(labels)
+}
+
+type auditEntry struct {
+	command string
+	args    []string
+	user    string
+	fields  map[string]interface{}
+}
+
+type recordingAudit struct {
+	entries []auditEntry
+}
+
+func (a *recordingAudit) LogCommand(ctx context.Context, command string, args []string, user string, fields ...orpheus.Field) {
+	entry := auditEntry{command: command, args: args, user: user, fields: make(map[string]interface{})}
+	for _, field := range fields {
+		entry.fields[field.Key] = field.Value
+	}
+	a.entries = append(a.entries, entry)
+}
+func (a *recordingAudit) LogAccess(ctx context.Context, resource, action string, allowed bool, fields ...orpheus.Field) {
+}
+func (a *recordingAudit) LogSecurity(ctx context.Context, event, severity string, fields ...orpheus.Field) {
+}
+func (a *recordingAudit) LogPerformance(ctx context.Context, operation string, duration int64, fields ...orpheus.Field) {
+}
+
+func newInstrumentedApp(handlerErr error) (*orpheus.App, *recordingTracer, *recordingMetrics, *recordingAudit) {
+	tracer := &recordingTracer{}
+	metrics := &recordingMetrics{observations: make(map[string][]string)}
+	audit := &recordingAudit{}
+
+	app := orpheus.New("myapp").
+		SetTracer(tracer).
+		SetMetricsCollector(metrics).
+		SetAuditLogger(audit)
+
+	remote := orpheus.NewCommand("remote", "Manage remotes")
+	remote.Subcommand("add", "Add remote", func(ctx *orpheus.Context) error {
+		return handlerErr
+	}).
+		AddFlag("url", "u", "", "Remote URL").
+		AddFlag("password", "p", "", "Password").
+		AddFlag("key", "k", "", "Signing key").
+		MarkFlagSensitive("key")
+	app.AddCommand(remote)
+	return app, tracer, metrics, audit
+}
+
+func TestInstrumentationSuccess(t *testing.T) {
+	app, tracer, metrics, audit := newInstrumentedApp(nil)
+
+	args := []string{"remote", "add", "--url", "https://example.com", "-p", "hunter2", "--key=abc", "origin"}
+	if err := app.Run(args); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("expected one span, got %d", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if span.name != "remote add" || span.status != orpheus.StatusCodeOK || !span.ended || len(span.errors) != 0 {
+		t.Errorf("unexpected span: %+v", span)
+	}
+
+	if got := metrics.observations[orpheus.MetricCommandDuration]; !reflect.DeepEqual(got, []string{"remote add"}) {
+		t.Errorf("unexpected duration labels: %v", got)
+	}
+	if _, exists := metrics.observations[orpheus.MetricCommandErrors]; exists {
+		t.Error("error counter should not be incremented on success")
+	}
+
+	if len(audit.entries) != 1 {
+		t.Fatalf("expected one audit entry, got %d", len(audit.entries))
+	}
+	entry := audit.entries[0]
+	expectedArgs := []string{"--url", "https://example.com", "-p", "***", "--key=***", "origin"}
+	if entry.command != "remote add" || !reflect.DeepEqual(entry.args, expectedArgs) {
+		t.Errorf("unexpected audit entry: %s %v", entry.command, entry.args)
+	}
+	if entry.fields["status"] != "success" {
+		t.Errorf("unexpected audit status: %v", entry.fields["status"])
+	}
+	if args[5] != "hunter2" {
+		t.Error("redaction must not modify the original arguments")
+	}
+}
+
+func TestInstrumentationError(t *testing.T) {
+	app, tracer, metrics, audit := newInstrumentedApp(errors.New("boom"))
+
+	if err := app.Run([]string{"remote", "add"}); err == nil {
+		t.Fatal("expected handler error")
+	}
+	span := tracer.spans[0]
+	if span.status != orpheus.StatusCodeError || len(span.errors) != 1 || span.attributes["error.code"] != "unknown" {
+		t.Errorf("unexpected span: %+v", span)
+	}
+	if got := metrics.observations[orpheus.MetricCommandErrors]; !reflect.DeepEqual(got, []string{"remote add", "unknown"}) {
+		t.Errorf("unexpected error counter labels: %v", got)
+	}
+
+	// Framework errors are labelled with their error code
+	err := app.Run([]string{"remote", "add", "--bogus=1"})
+	requireValidationError(t, err, "unknown flag")
+	if got := metrics.observations[orpheus.MetricCommandErrors]; !reflect.DeepEqual(got[2:], []string{"remote add", string(orpheus.ErrCodeValidation)}) {
+		t.Errorf("unexpected error counter labels: %v", got)
+	}
+	if last := audit.entries[len(audit.entries)-1]; last.fields["status"] != "error" || last.fields["error_code"] != string(orpheus.ErrCodeValidation) {
+		t.Errorf("unexpected audit fields: %v", last.fields)
+	}
+}
+
+func TestInstrumentationDisabledWithoutProviders(t *testing.T) {
+	called := false
+	app := orpheus.New("myapp")
+	app.Command("run", "Run", func(ctx *orpheus.Context) error {
+		called = true
+		return nil
+	})
+
+	if err := app.Run([]string{"run"}); err != nil || !called {
+		t.Errorf("command should run without observability providers: err=%v called=%v", err, called)
+	}
+}
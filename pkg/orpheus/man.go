@@ -0,0 +1,284 @@
+// man.go: man page generation from the command tree in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GenerateManPages writes a section 1 man page in roff format for the application
+// and for every visible command and subcommand to dir, which is created if needed.
+// Pages are named after the command path: myapp.1, myapp-remote.1, myapp-remote-add.1.
+// The page date honours SOURCE_DATE_EPOCH for reproducible builds.
+func (app *App) GenerateManPages(dir string) error {
+	dir, err := prepareDocDir(dir)
+	if err != nil {
+		return err
+	}
+
+	h := NewHelpGenerator(app)
+	date := docDate().Format("January 2006")
+
+	pages := map[string]string{app.name + ".1": app.manPage(h.appHelpData(), nil, date)}
+	for _, cmd := range app.commandTree() {
+		pages[manPageName(app, cmd)+".1"] = app.manPage(h.commandHelpData(cmd), cmd, date)
+	}
+	return writeDocFiles(dir, pages)
+}
+
+// manPage renders the man page of a command, or of the application when cmd is nil.
+func (app *App) manPage(data *HelpData, cmd *Command, date string) string {
+	var sb strings.Builder
+
+	title := app.name
+	name := app.name
+	summary := app.description
+	if cmd != nil {
+		title = manPageName(app, cmd)
+		name = title
+		summary = cmd.Description()
+	}
+
+	source := app.name
+	if app.version != "" {
+		source += " " + app.version
+	}
+	fmt.Fprintf(&sb, ".TH \"%s\" \"1\" \"%s\" \"%s\" \"%s\"\n",
+		roffEscape(strings.ToUpper(title)), date, roffEscape(source), roffEscape(app.name+" Manual"))
+
+	sb.WriteString(".SH NAME\n")
+	if summary != "" {
+		fmt.Fprintf(&sb, "%s \\- %s\n", roffEscape(name), roffEscape(summary))
+	} else {
+		sb.WriteString(roffEscape(name) + "\n")
+	}
+
+	sb.WriteString(".SH SYNOPSIS\n")
+	program, rest := app.name, data.Usage
+	if cmd != nil {
+		program, rest = app.name+" "+cmd.FullName(), commandUsageTail(cmd)
+	} else {
+		rest = strings.TrimPrefix(rest, app.name+" ")
+	}
+	fmt.Fprintf(&sb, ".B %s\n%s\n", roffEscape(program), roffEscape(rest))
+
+	description := app.description
+	if cmd != nil {
+		description = cmd.Description()
+		if cmd.longDescription != "" {
+			description = cmd.longDescription
+		}
+		if cmd.deprecated {
+			description += "\n\nThis command is deprecated."
+			if cmd.replacement != "" {
+				description += fmt.Sprintf(" Use %s instead.", cmd.replacement)
+			}
+		}
+	}
+	if description != "" {
+		sb.WriteString(".SH DESCRIPTION\n")
+		writeRoffParagraphs(&sb, description)
+	}
+
+	if cmd != nil && len(data.Command.Args) > 0 {
+		sb.WriteString(".SH ARGUMENTS\n")
+		for _, arg := range data.Command.Args {
+			fmt.Fprintf(&sb, ".TP\n\\fI%s\\fR\n%s\n", roffEscape(arg.Usage), roffEscape(arg.Notes()))
+		}
+	}
+
+	if len(data.Commands) > 0 {
+		sb.WriteString(".SH COMMANDS\n")
+		for _, section := range data.Commands {
+			for _, sub := range section.Commands {
+				fmt.Fprintf(&sb, ".TP\n\\fB%s\\fR\n%s\n", roffEscape(sub.Name), roffEscape(sub.Summary))
+			}
+		}
+	}
+
+	if cmd != nil {
+		writeRoffFlags(&sb, "OPTIONS", data.Flags)
+		for _, group := range data.FlagGroups {
+			writeRoffFlags(&sb, strings.ToUpper(group.Title), group.Flags)
+		}
+		writeRoffFlags(&sb, "INHERITED OPTIONS", data.InheritedFlags)
+		writeRoffFlags(&sb, "GLOBAL OPTIONS", data.GlobalFlags)
+	} else {
+		writeRoffFlags(&sb, "OPTIONS", data.GlobalFlags)
+	}
+
+	if cmd != nil && len(cmd.examples) > 0 {
+		sb.WriteString(".SH EXAMPLES\n")
+		for _, example := range cmd.examples {
+			fmt.Fprintf(&sb, ".PP\n.RS\n.nf\n%s\n.fi\n.RE\n", roffEscape(example))
+		}
+	}
+
+	if related := manSeeAlso(app, cmd); len(related) > 0 {
+		sb.WriteString(".SH SEE ALSO\n")
+		refs := make([]string, len(related))
+		for i, page := range related {
+			refs[i] = fmt.Sprintf("\\fB%s\\fR(1)", roffEscape(page))
+		}
+		sb.WriteString(strings.Join(refs, ", ") + "\n")
+	}
+
+	return sb.String()
+}
+
+// writeRoffFlags writes an options section, skipped when there are no flags.
+func writeRoffFlags(sb *strings.Builder, title string, flags []HelpFlag) {
+	if len(flags) == 0 {
+		return
+	}
+
+	fmt.Fprintf(sb, ".SH %s\n", roffEscape(title))
+	for _, flag := range flags {
+		sb.WriteString(".TP\n")
+		if flag.Shorthand != "" {
+			fmt.Fprintf(sb, "\\fB%s\\fR, ", roffEscape("-"+flag.Shorthand))
+		}
+		fmt.Fprintf(sb, "\\fB%s\\fR", roffEscape("--"+flag.Name))
+		if flag.Type != "bool" {
+			fmt.Fprintf(sb, " \\fI%s\\fR", roffEscape(strings.ToUpper(flag.Type)))
+		}
+		sb.WriteString("\n" + roffEscape(flagNotes(flag, true)) + "\n")
+	}
+}
+
+// writeRoffParagraphs writes text as roff paragraphs separated by blank lines.
+func writeRoffParagraphs(sb *strings.Builder, text string) {
+	for i, paragraph := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		if i > 0 {
+			sb.WriteString(".PP\n")
+		}
+		sb.WriteString(roffEscape(strings.TrimSpace(paragraph)) + "\n")
+	}
+}
+
+// manSeeAlso returns the pages related to a command: its parent (or the
+// application page) and its visible subcommands.
+func manSeeAlso(app *App, cmd *Command) []string {
+	var related []string
+	children := app.commands
+	if cmd != nil {
+		if cmd.parent != nil {
+			related = append(related, manPageName(app, cmd.parent))
+		} else {
+			related = append(related, app.name)
+		}
+		children = cmd.subcommands
+	}
+	for _, child := range visibleCommands(children) {
+		related = append(related, manPageName(app, child))
+	}
+	return related
+}
+
+// manPageName returns the page name of a command, e.g. "myapp-remote-add".
+func manPageName(app *App, cmd *Command) string {
+	return app.name + "-" + strings.Join(cmd.path(), "-")
+}
+
+// commandUsageTail returns the usage of a command after its name, e.g. "[flags] <name>".
+func commandUsageTail(cmd *Command) string {
+	usage := cmd.Usage()
+	if cmd.HasSubcommands() {
+		usage = cmd.name + " <subcommand> [flags]"
+	}
+	return strings.TrimSpace(strings.TrimPrefix(usage, cmd.name))
+}
+
+// flagNotes returns the flag description with its choices, default (when
+// withDefault is set) and markers.
+func flagNotes(flag HelpFlag, withDefault bool) string {
+	notes := flag.Description
+	if len(flag.Choices) > 0 {
+		notes += " (choices: " + strings.Join(flag.Choices, ", ") + ")"
+	}
+	if withDefault && flag.HasDefault && flag.Default != "" {
+		notes += " (default: " + flag.Default + ")"
+	}
+	if flag.Required {
+		notes += " (required)"
+	}
+	if flag.Deprecated {
+		notes += " (deprecated)"
+	}
+	return notes
+}
+
+// roffEscape escapes text for roff: backslashes, hyphens and control characters
+// at the start of a line.
+func roffEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\e`)
+	s = strings.ReplaceAll(s, "-", `\-`)
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// commandTree returns the visible commands and subcommands depth-first, sorted by name.
+func (app *App) commandTree() []*Command {
+	var tree []*Command
+	var walk func(commands map[string]*Command)
+	walk = func(commands map[string]*Command) {
+		for _, cmd := range visibleCommands(commands) {
+			tree = append(tree, cmd)
+			walk(cmd.subcommands)
+		}
+	}
+	walk(app.commands)
+	return tree
+}
+
+// docDate returns the date stamped on generated documentation: SOURCE_DATE_EPOCH
+// when set, otherwise the current time.
+func docDate() time.Time {
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		if seconds, err := strconv.ParseInt(epoch, 10, 64); err == nil {
+			return time.Unix(seconds, 0).UTC()
+		}
+	}
+	return time.Now()
+}
+
+// prepareDocDir validates and creates the output directory of generated documentation.
+func prepareDocDir(dir string) (string, error) {
+	result := ValidateSecurePath(dir, DefaultSecurityConfig())
+	if !result.IsValid {
+		return "", ValidationError("", fmt.Sprintf("output directory %s rejected: %s", dir, strings.Join(result.Errors, "; "))).
+			WithContext("dir", dir)
+	}
+	if err := os.MkdirAll(result.NormalizedPath, 0o755); err != nil {
+		return "", ExecutionError("", fmt.Sprintf("cannot create output directory %s: %v", dir, err)).
+			WithContext("dir", dir)
+	}
+	return result.NormalizedPath, nil
+}
+
+// writeDocFiles writes generated documentation files to dir.
+func writeDocFiles(dir string, files map[string]string) error {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return ExecutionError("", fmt.Sprintf("cannot write %s: %v", path, err)).
+				WithContext("file", path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// man_test.go: tests for man page generation in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus_test
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/agilira/orpheus/pkg/orpheus"
+)
+
+func newDocsApp() *orpheus.App {
+	app := orpheus.New("git").SetDescription("Content tracker").SetVersion("2.0.0").
+		AddGlobalBoolFlag("debug", "d", false, "Enable debug")
+
+	remote := orpheus.NewCommand("remote", "Manage remotes").
+		SetLongDescription("Manage the set of tracked repositories.\n\nUse -v for details.").
+		AddPersistentBoolFlag("verbose", "v", false, "Verbose output")
+	remote.AddSubcommand(orpheus.NewCommand("add", "Add a remote").
+		AddArg("name", "Remote name").
+		AddFlag("url", "u", "", "Remote URL").
+		AddEnumFlag("mode", "", "fetch", []string{"fetch", "push"}, "Mirror mode").
+		AddExample("git remote add origin https://example.com/repo.git").
+		SetHandler(noop))
+	remote.Subcommand("prune", "Prune stale refs", noop).SetHidden(true)
+
+	app.AddCommand(remote)
+	app.Command("status", "Show the working tree status", noop)
+	return app
+}
+
+func readDocs(t *testing.T, dir string) map[string]string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string]string)
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[entry.Name()] = string(data)
+	}
+	return files
+}
+
+func TestGenerateManPages(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "1700000000")
+	dir := filepath.Join(t.TempDir(), "man1")
+
+	if err := newDocsApp().GenerateManPages(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pages := readDocs(t, dir)
+
+	var names []string
+	for name := range pages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if want := "git-remote-add.1 git-remote.1 git-status.1 git.1"; strings.Join(names, " ") != want {
+		t.Fatalf("pages = %v, want %s", names, want)
+	}
+
+	add := pages["git-remote-add.1"]
+	for _, want := range []string{
+		`.TH "GIT\-REMOTE\-ADD" "1" "November 2023" "git 2.0.0" "git Manual"`,
+		".SH NAME\ngit\\-remote\\-add \\- Add a remote\n",
+		".SH SYNOPSIS\n.B git remote add\n[flags] <name>\n",
+		".SH ARGUMENTS\n.TP\n\\fI<name>\\fR\nRemote name\n",
+		".SH OPTIONS\n.TP\n\\fB\\-\\-mode\\fR \\fIENUM\\fR\nMirror mode (choices: fetch, push) (default: fetch)\n",
+		"\\fB\\-u\\fR, \\fB\\-\\-url\\fR \\fISTRING\\fR\nRemote URL\n",
+		".SH INHERITED OPTIONS\n.TP\n\\fB\\-v\\fR, \\fB\\-\\-verbose\\fR\n",
+		".SH GLOBAL OPTIONS\n.TP\n\\fB\\-d\\fR, \\fB\\-\\-debug\\fR\n",
+		".SH EXAMPLES\n.PP\n.RS\n.nf\ngit remote add origin https://example.com/repo.git\n.fi\n.RE\n",
+		".SH SEE ALSO\n\\fBgit\\-remote\\fR(1)\n",
+	} {
+		if !strings.Contains(add, want) {
+			t.Errorf("git-remote-add.1 missing %q:\n%s", want, add)
+		}
+	}
+
+	remote := pages["git-remote.1"]
+	for _, want := range []string{
+		".SH DESCRIPTION\nManage the set of tracked repositories.\n.PP\nUse \\-v for details.\n",
+		".SH COMMANDS\n.TP\n\\fBadd\\fR\nAdd a remote\n",
+		".SH SEE ALSO\n\\fBgit\\fR(1), \\fBgit\\-remote\\-add\\fR(1)\n",
+	} {
+		if !strings.Contains(remote, want) {
+			t.Errorf("git-remote.1 missing %q:\n%s", want, remote)
+		}
+	}
+	if strings.Contains(remote, "prune") {
+		t.Errorf("hidden subcommand documented:\n%s", remote)
+	}
+
+	root := pages["git.1"]
+	if !strings.Contains(root, "\\fBgit\\-remote\\fR(1), \\fBgit\\-status\\fR(1)") {
+		t.Errorf("git.1 missing links to commands:\n%s", root)
+	}
+}
+
+func TestGenerateManPagesRejectsUnsafeDirectory(t *testing.T) {
+	err := newDocsApp().GenerateManPages("../../../etc/man")
+	requireValidationError(t, err, "output directory ../../../etc/man rejected")
+}
@@ -0,0 +1,121 @@
+// middleware.go: middleware chain and lifecycle hooks in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+// Middleware wraps a command handler to run code before and after it,
+// or to short-circuit execution by returning an error without calling next.
+type Middleware func(next CommandHandler) CommandHandler
+
+// Use adds middleware applied to every command of the application.
+// Middleware runs in the order it was added, the first one being the outermost.
+func (app *App) Use(middleware ...Middleware) *App {
+	app.middleware = append(app.middleware, middleware...)
+	return app
+}
+
+// Use adds middleware applied to this command and all its subcommands.
+// Command middleware runs inside application middleware, parents before children.
+func (c *Command) Use(middleware ...Middleware) *Command {
+	c.middleware = append(c.middleware, middleware...)
+	return c
+}
+
+// SetPreRun sets a hook that runs before the handler of this command only.
+func (c *Command) SetPreRun(hook CommandHandler) *Command {
+	c.preRun = hook
+	return c
+}
+
+// SetPostRun sets a hook that runs after the handler of this command succeeds.
+func (c *Command) SetPostRun(hook CommandHandler) *Command {
+	c.postRun = hook
+	return c
+}
+
+// SetPersistentPreRun sets a hook that runs before this command and any of its subcommands.
+// Persistent hooks of all ancestors run from the root command down.
+func (c *Command) SetPersistentPreRun(hook CommandHandler) *Command {
+	c.persistentPreRun = hook
+	return c
+}
+
+// SetPersistentPostRun sets a hook that runs after this command or any of its subcommands succeeds.
+// Persistent hooks of all ancestors run from the executed command up to the root.
+func (c *Command) SetPersistentPostRun(hook CommandHandler) *Command {
+	c.persistentPostRun = hook
+	return c
+}
+
+// lineage returns the commands from the root command down to this command.
+func (c *Command) lineage() []*Command {
+	if c.parent == nil {
+		return []*Command{c}
+	}
+	return append(c.parent.lineage(), c)
+}
+
+// executionChain composes middleware and hooks around the command handler:
+//
+//	app middleware -> command middleware (root to leaf) ->
+//	PersistentPreRun (root to leaf) -> PreRun -> handler -> PostRun ->
+//	PersistentPostRun (leaf to root)
+//
+// A hook or handler returning an error stops the remaining steps.
+func (c *Command) executionChain(app *App) CommandHandler {
+	var chain []Middleware
+	if app != nil {
+		chain = append(chain, app.middleware...)
+	}
+	for _, cmd := range c.lineage() {
+		chain = append(chain, cmd.middleware...)
+	}
+
+	handler := c.runWithHooks
+	for i := len(chain) - 1; i >= 0; i-- {
+		handler = chain[i](handler)
+	}
+	return handler
+}
+
+// runWithHooks runs the lifecycle hooks around the command handler.
+func (c *Command) runWithHooks(ctx *Context) error {
+	lineage := c.lineage()
+
+	for _, cmd := range lineage {
+		if cmd.persistentPreRun != nil {
+			if err := cmd.persistentPreRun(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	if c.preRun != nil {
+		if err := c.preRun(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := c.handler(ctx); err != nil {
+		return err
+	}
+
+	if c.postRun != nil {
+		if err := c.postRun(ctx); err != nil {
+			return err
+		}
+	}
+
+	for i := len(lineage) - 1; i >= 0; i-- {
+		if hook := lineage[i].persistentPostRun; hook != nil {
+			if err := hook(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+// middleware_test.go: tests for middleware and lifecycle hooks in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/agilira/orpheus/pkg/orpheus"
+)
+
+// tracingMiddleware records entry and exit of a named middleware.
+func tracingMiddleware(name string, calls *[]string) orpheus.Middleware {
+	return func(next orpheus.CommandHandler) orpheus.CommandHandler {
+		return func(ctx *orpheus.Context) error {
+			*calls = append(*calls, name+":before")
+			err := next(ctx)
+			*calls = append(*calls, name+":after")
+			return err
+		}
+	}
+}
+
+// recordingHook records a named hook call.
+func recordingHook(name string, calls *[]string) orpheus.CommandHandler {
+	return func(ctx *orpheus.Context) error {
+		*calls = append(*calls, name)
+		return nil
+	}
+}
+
+func newMiddlewareApp(calls *[]string, handlerErr error) *orpheus.App {
+	app := orpheus.New("myapp").
+		Use(tracingMiddleware("app1", calls), tracingMiddleware("app2", calls))
+
+	remote := orpheus.NewCommand("remote", "Manage remotes").
+		Use(tracingMiddleware("remote", calls)).
+		SetPersistentPreRun(recordingHook("remote:persistent-pre", calls)).
+		SetPersistentPostRun(recordingHook("remote:persistent-post", calls))
+
+	add := orpheus.NewCommand("add", "Add remote").
+		Use(tracingMiddleware("add", calls)).
+		SetPersistentPreRun(recordingHook("add:persistent-pre", calls)).
+		SetPersistentPostRun(recordingHook("add:persistent-post", calls)).
+		SetPreRun(recordingHook("add:pre", calls)).
+		SetPostRun(recordingHook("add:post", calls)).
+		SetHandler(func(ctx *orpheus.Context) error {
+			*calls = append(*calls, "handler")
+			return handlerErr
+		})
+
+	remote.AddSubcommand(add)
+	app.AddCommand(remote)
+	return app
+}
+
+func TestMiddlewareAndHookOrder(t *testing.T) {
+	var calls []string
+	app := newMiddlewareApp(&calls, nil)
+
+	if err := app.Run([]string{"remote", "add"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"app1:before", "app2:before", "remote:before", "add:before",
+		"remote:persistent-pre", "add:persistent-pre", "add:pre",
+		"handler",
+		"add:post", "add:persistent-post", "remote:persistent-post",
+		"add:after", "remote:after", "app2:after", "app1:after",
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("unexpected call order:\n got: %v\nwant: %v", calls, expected)
+	}
+}
+
+func TestPostRunSkippedOnError(t *testing.T) {
+	var calls []string
+	handlerErr := errors.New("boom")
+	app := newMiddlewareApp(&calls, handlerErr)
+
+	if err := app.Run([]string{"remote", "add"}); !errors.Is(err, handlerErr) {
+		t.Fatalf("expected handler error, got %v", err)
+	}
+
+	for _, call := range calls {
+		if call == "add:post" || call == "add:persistent-post" || call == "remote:persistent-post" {
+			t.Errorf("post hooks must not run after a failure, got %v", calls)
+		}
+	}
+	if calls[len(calls)-1] != "app1:after" {
+		t.Errorf("middleware should still unwind, got %v", calls)
+	}
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	denied := orpheus.ExecutionError("deploy", "not authorized")
+	handled := false
+
+	app := orpheus.New("myapp").Use(func(next orpheus.CommandHandler) orpheus.CommandHandler {
+		return func(ctx *orpheus.Context) error {
+			if !ctx.GetFlagBool("admin") {
+				return denied
+			}
+			return next(ctx)
+		}
+	})
+	app.AddCommand(orpheus.NewCommand("deploy", "Deploy").
+		AddBoolFlag("admin", "", false, "Run as admin").
+		SetHandler(func(ctx *orpheus.Context) error {
+			handled = true
+			return nil
+		}))
+
+	if err := app.Run([]string{"deploy"}); err != denied || handled {
+		t.Errorf("middleware should block the handler, got err=%v handled=%v", err, handled)
+	}
+	if err := app.Run([]string{"deploy", "--admin"}); err != nil || !handled {
+		t.Errorf("middleware should call the handler, got err=%v handled=%v", err, handled)
+	}
+}
+
+func TestPreRunErrorStopsHandler(t *testing.T) {
+	handled := false
+	app := orpheus.New("myapp")
+	app.AddCommand(orpheus.NewCommand("deploy", "Deploy").
+		SetPreRun(func(ctx *orpheus.Context) error {
+			return orpheus.ValidationError("deploy", "precondition failed")
+		}).
+		SetHandler(func(ctx *orpheus.Context) error {
+			handled = true
+			return nil
+		}))
+
+	err := app.Run([]string{"deploy"})
+	requireValidationError(t, err, "precondition failed")
+	if handled {
+		t.Error("handler must not run when PreRun fails")
+	}
+}
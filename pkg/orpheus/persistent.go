@@ -0,0 +1,323 @@
+// persistent.go: persistent flags inherited by subcommands and flag routing in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+import (
+	"strings"
+	"time"
+
+	flashflags "github.com/agilira/flash-flags"
+)
+
+// flagScope is a flag set whose flags a command accepts in addition to its own:
+// the persistent flags of the command and its ancestors, and the global flags.
+type flagScope struct {
+	owner *Command // nil for the global flags
+	flags *flashflags.FlagSet
+	meta  *flagMeta
+	args  []string // arguments routed to this scope
+	names []string // flags named by the routed arguments
+}
+
+// AddPersistentFlag adds a string flag accepted by this command and all its subcommands.
+func (c *Command) AddPersistentFlag(name, shorthand, defaultValue, description string) *Command {
+	if shorthand != "" {
+		c.persistentFlags().StringVar(name, shorthand, defaultValue, description)
+	} else {
+		c.persistentFlags().String(name, defaultValue, description)
+	}
+	return c
+}
+
+// AddPersistentBoolFlag adds a boolean flag accepted by this command and all its subcommands.
+func (c *Command) AddPersistentBoolFlag(name, shorthand string, defaultValue bool, description string) *Command {
+	if shorthand != "" {
+		c.persistentFlags().BoolVar(name, shorthand, defaultValue, description)
+	} else {
+		c.persistentFlags().Bool(name, defaultValue, description)
+	}
+	return c
+}
+
+// AddPersistentIntFlag adds an integer flag accepted by this command and all its subcommands.
+func (c *Command) AddPersistentIntFlag(name, shorthand string, defaultValue int, description string) *Command {
+	if shorthand != "" {
+		c.persistentFlags().IntVar(name, shorthand, defaultValue, description)
+	} else {
+		c.persistentFlags().Int(name, defaultValue, description)
+	}
+	return c
+}
+
+// AddPersistentFloat64Flag adds a float64 flag accepted by this command and all its subcommands.
+func (c *Command) AddPersistentFloat64Flag(name, shorthand string, defaultValue float64, description string) *Command {
+	addFloat64Flag(c.persistentFlags(), c.persistentMeta, name, shorthand, defaultValue, description)
+	return c
+}
+
+// AddPersistentStringSliceFlag adds a string slice flag accepted by this command and all its subcommands.
+func (c *Command) AddPersistentStringSliceFlag(name, shorthand string, defaultValue []string, description string) *Command {
+	addStringSliceFlag(c.persistentFlags(), c.persistentMeta, name, shorthand, defaultValue, description)
+	return c
+}
+
+// AddPersistentDurationFlag adds a time.Duration flag accepted by this command and all its subcommands.
+func (c *Command) AddPersistentDurationFlag(name, shorthand string, defaultValue time.Duration, description string) *Command {
+	addDurationFlag(c.persistentFlags(), c.persistentMeta, name, shorthand, defaultValue, description)
+	return c
+}
+
+// AddPersistentInt64Flag adds a 64-bit integer flag accepted by this command and all its subcommands.
+func (c *Command) AddPersistentInt64Flag(name, shorthand string, defaultValue int64, description string) *Command {
+	addInt64Flag(c.persistentFlags(), c.persistentMeta, name, shorthand, defaultValue, description)
+	return c
+}
+
+// AddPersistentUintFlag adds an unsigned integer flag accepted by this command and all its subcommands.
+func (c *Command) AddPersistentUintFlag(name, shorthand string, defaultValue uint, description string) *Command {
+	addUintFlag(c.persistentFlags(), c.persistentMeta, name, shorthand, defaultValue, description)
+	return c
+}
+
+// AddPersistentByteSizeFlag adds a byte size flag accepted by this command and all its subcommands.
+func (c *Command) AddPersistentByteSizeFlag(name, shorthand string, defaultValue int64, description string) *Command {
+	addByteSizeFlag(c.persistentFlags(), c.persistentMeta, name, shorthand, defaultValue, description)
+	return c
+}
+
+// AddPersistentStringMapFlag adds a key=value map flag accepted by this command and all its subcommands.
+func (c *Command) AddPersistentStringMapFlag(name, shorthand string, defaultValue map[string]string, description string) *Command {
+	addStringMapFlag(c.persistentFlags(), c.persistentMeta, name, shorthand, defaultValue, description)
+	return c
+}
+
+// AddPersistentIntSliceFlag adds an integer list flag accepted by this command and all its subcommands.
+func (c *Command) AddPersistentIntSliceFlag(name, shorthand string, defaultValue []int, description string) *Command {
+	addIntSliceFlag(c.persistentFlags(), c.persistentMeta, name, shorthand, defaultValue, description)
+	return c
+}
+
+// AddPersistentEnumFlag adds an enum flag accepted by this command and all its subcommands.
+func (c *Command) AddPersistentEnumFlag(name, shorthand, defaultValue string, choices []string, description string) *Command {
+	addEnumFlag(c.persistentFlags(), c.persistentMeta, name, shorthand, defaultValue, choices, description)
+	return c
+}
+
+// MarkPersistentFlagRequired marks persistent flags as required for this command and all its subcommands.
+func (c *Command) MarkPersistentFlagRequired(names ...string) *Command {
+	// Create the flag set so that undefined names are reported when the command runs
+	c.persistentFlags()
+	for _, name := range names {
+		c.persistentMeta.option(name).required = true
+	}
+	return c
+}
+
+// PersistentFlags returns the persistent flag set of the command, or nil if it has none.
+func (c *Command) PersistentFlags() *flashflags.FlagSet {
+	return c.persistent
+}
+
+// persistentFlags returns the persistent flag set, creating it on first use.
+func (c *Command) persistentFlags() *flashflags.FlagSet {
+	if c.persistent == nil {
+		c.persistent = flashflags.New(c.name)
+	}
+	return c.persistent
+}
+
+// inheritedScopes returns the flag sets a command accepts besides its own flags, in
+// lookup order: its persistent flags, those of its ancestors up to the root, then
+// the global flags.
+func (c *Command) inheritedScopes(app *App) []*flagScope {
+	var scopes []*flagScope
+	for cmd := c; cmd != nil; cmd = cmd.parent {
+		if cmd.persistent != nil {
+			scopes = append(scopes, &flagScope{owner: cmd, flags: cmd.persistent, meta: cmd.persistentMeta})
+		}
+	}
+	if app != nil && app.globalFlags != nil {
+		scopes = append(scopes, &flagScope{flags: app.globalFlags, meta: app.globalFlagMeta})
+	}
+	return scopes
+}
+
+// routeArgs splits command arguments between the command's own flags and the
+// inherited scopes. Positional arguments, unknown flags and flags of the command
+// itself stay local; everything after "--" is left untouched.
+func (c *Command) routeArgs(scopes []*flagScope, args []string) []string {
+	var local []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return append(local, args[i:]...)
+		}
+
+		name, hasValue, ok := flagTokenName(arg)
+		if !ok || findFlag(c.flags, c.flagMeta, name) != nil {
+			local = append(local, arg)
+			continue
+		}
+
+		scope, flag := findScope(scopes, name)
+		if scope == nil {
+			local = append(local, arg)
+			continue
+		}
+
+		scope.args = append(scope.args, arg)
+		scope.names = append(scope.names, flag.Name())
+		if !hasValue && flag.Type() != "bool" && i+1 < len(args) {
+			scope.args = append(scope.args, args[i+1])
+			i++
+		}
+	}
+	return local
+}
+
+// skipInheritedFlags returns the index of the first argument that is not an
+// inherited flag (or its value), or -1 when a flag of another kind comes first.
+func skipInheritedFlags(scopes []*flagScope, args []string) int {
+	for i := 0; i < len(args); i++ {
+		name, hasValue, ok := flagTokenName(args[i])
+		if !ok {
+			if args[i] == "--" || strings.HasPrefix(args[i], "-") {
+				return -1
+			}
+			return i
+		}
+
+		_, flag := findScope(scopes, name)
+		if flag == nil {
+			return -1
+		}
+		if !hasValue && flag.Type() != "bool" {
+			i++
+		}
+	}
+	return -1
+}
+
+// findScope returns the first scope defining a flag by long name or shorthand.
+func findScope(scopes []*flagScope, name string) (*flagScope, *flashflags.Flag) {
+	for _, scope := range scopes {
+		if flag := findFlag(scope.flags, scope.meta, name); flag != nil {
+			return scope, flag
+		}
+	}
+	return nil, nil
+}
+
+// flagTokenName returns the flag name of a "--name", "--name=value", "-n" or
+// "-n=value" argument. Combined shorthand groups and non-flags report false.
+func flagTokenName(arg string) (name string, hasValue bool, ok bool) {
+	switch {
+	case strings.HasPrefix(arg, "--") && len(arg) > 2:
+		name, _, hasValue = strings.Cut(arg[2:], "=")
+		return name, hasValue, true
+	case len(arg) == 2 && arg[0] == '-' && arg[1] != '-':
+		return arg[1:], false, true
+	case len(arg) > 2 && arg[0] == '-' && arg[1] != '-' && arg[2] == '=':
+		return arg[1:2], true, true
+	default:
+		return "", false, false
+	}
+}
+
+// parseInheritedFlags parses the arguments routed to each inherited scope. Persistent
+// flags start from their defaults and are filled from the environment and
+// configuration; global flags keep the values parsed before the command name.
+func (c *Command) parseInheritedFlags(ctx *Context, scopes []*flagScope) (map[string]FlagSource, error) {
+	sources := make(map[string]FlagSource)
+
+	for i := len(scopes) - 1; i >= 0; i-- {
+		scope := scopes[i]
+
+		if scope.owner == nil {
+			if err := c.parseGlobalScope(ctx, scope); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		scope.flags.Reset()
+		if err := scope.flags.Parse(scope.meta.expandShorthands(scope.args)); err != nil {
+			return nil, ctx.App.flagParseError(c.name, "flag parsing failed", scope.flags, err)
+		}
+
+		binding := &flagBinding{
+			app:     ctx.App,
+			command: c.name,
+			scope:   scope.owner.path(),
+			flags:   scope.flags,
+			meta:    scope.meta,
+			sources: sources,
+		}
+		binding.recordArgs()
+		if err := binding.applyEnvironment(); err != nil {
+			return nil, err
+		}
+		if err := binding.applyConfig(); err != nil {
+			return nil, err
+		}
+		if err := scope.meta.validate(c.name, scope.flags); err != nil {
+			return nil, err
+		}
+		warnDeprecatedFlags(ctx, c.name, scope.flags, scope.meta)
+	}
+
+	return sources, nil
+}
+
+// parseGlobalScope applies global flags given after the command name and enforces
+// the global flag constraints.
+func (c *Command) parseGlobalScope(ctx *Context, scope *flagScope) error {
+	if len(scope.args) > 0 {
+		if err := scope.flags.Parse(scope.meta.expandShorthands(scope.args)); err != nil {
+			return ctx.App.flagParseError("", "global flag parsing failed", scope.flags, err)
+		}
+		if ctx.globalSources == nil {
+			ctx.globalSources = make(map[string]FlagSource)
+		}
+		reload := false
+		for _, name := range scope.names {
+			ctx.globalSources[name] = FlagSourceArgs
+			reload = reload || name == "config"
+		}
+		// --config after the command name takes effect as if given before it
+		if reload && ctx.App.configEnabled {
+			if err := ctx.App.reloadConfig(ctx.globalSources); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scope.meta.validate("", scope.flags); err != nil {
+		return err
+	}
+	warnDeprecatedFlags(ctx, "", scope.flags, scope.meta)
+	return nil
+}
+
+// lookupFlagSet returns the flag set defining name, resolving through the command's
+// own flags, the persistent flags of the command and its ancestors, and the global
+// flags. It falls back to the command's own flag set when no set defines the flag.
+func (ctx *Context) lookupFlagSet(name string) *flashflags.FlagSet {
+	if ctx.Flags != nil && ctx.Flags.Lookup(name) != nil {
+		return ctx.Flags
+	}
+	if ctx.Command != nil {
+		for cmd := ctx.Command; cmd != nil; cmd = cmd.parent {
+			if cmd.persistent != nil && cmd.persistent.Lookup(name) != nil {
+				return cmd.persistent
+			}
+		}
+	}
+	if ctx.GlobalFlags != nil && ctx.GlobalFlags.Lookup(name) != nil {
+		return ctx.GlobalFlags
+	}
+	return ctx.Flags
+}
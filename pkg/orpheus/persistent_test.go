@@ -0,0 +1,212 @@
+// persistent_test.go: tests for persistent flags and global flags after the command name in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agilira/orpheus/pkg/orpheus"
+)
+
+type remoteRun struct {
+	verbose bool
+	url     string
+	fetch   bool
+	source  orpheus.FlagSource
+	name    string
+}
+
+func newRemoteApp(run *remoteRun) (*orpheus.App, *orpheus.Command) {
+	app := orpheus.New("testapp").SetEnvPrefix("TESTAPP")
+
+	remote := orpheus.NewCommand("remote", "Manage remotes").
+		AddPersistentBoolFlag("verbose", "v", false, "Verbose output").
+		AddPersistentFlag("url", "u", "https://example.com", "Remote URL")
+
+	add := orpheus.NewCommand("add", "Add a remote").
+		AddBoolFlag("fetch", "f", false, "Fetch after adding").
+		AddArg("name", "Remote name").
+		SetHandler(func(ctx *orpheus.Context) error {
+			run.verbose = ctx.GetFlagBool("verbose")
+			run.url = ctx.GetFlagString("url")
+			run.fetch = ctx.GetFlagBool("fetch")
+			run.source = ctx.FlagSource("url")
+			run.name = ctx.Arg("name")
+			return nil
+		})
+
+	remote.AddSubcommand(add)
+	app.AddCommand(remote)
+	return app, add
+}
+
+func TestPersistentFlagsAnywhereInChain(t *testing.T) {
+	tests := [][]string{
+		{"remote", "--verbose", "add", "--url", "git@host", "origin"},
+		{"remote", "-v", "-u", "git@host", "add", "origin"},
+		{"remote", "add", "origin", "-v", "--url=git@host"},
+		{"remote", "--url", "git@host", "add", "-f", "origin", "--verbose"},
+	}
+
+	for _, args := range tests {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			var run remoteRun
+			app, _ := newRemoteApp(&run)
+
+			if err := app.Run(args); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !run.verbose {
+				t.Error("expected --verbose to be inherited")
+			}
+			if run.url != "git@host" {
+				t.Errorf("url = %q", run.url)
+			}
+			if run.source != orpheus.FlagSourceArgs {
+				t.Errorf("url source = %v, want command-line", run.source)
+			}
+		})
+	}
+}
+
+func TestPersistentFlagDefaultsAndEnvironment(t *testing.T) {
+	var run remoteRun
+	app, _ := newRemoteApp(&run)
+
+	if err := app.Run([]string{"remote", "--verbose", "add", "origin"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Setenv("TESTAPP_REMOTE_URL", "https://mirror.example.com")
+	if err := app.Run([]string{"remote", "add", "origin"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if run.verbose {
+		t.Error("persistent flag value leaked between runs")
+	}
+	if run.url != "https://mirror.example.com" || run.source != orpheus.FlagSourceEnv {
+		t.Errorf("url = %q from %v, want value from environment", run.url, run.source)
+	}
+}
+
+func TestPersistentFlagsDoNotLeakToPositionals(t *testing.T) {
+	var run remoteRun
+	app, _ := newRemoteApp(&run)
+
+	if err := app.Run([]string{"remote", "add", "-u", "git@host", "origin", "-f"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !run.fetch {
+		t.Error("expected local --fetch")
+	}
+	if run.name != "origin" {
+		t.Errorf("name = %q, want origin", run.name)
+	}
+}
+
+func TestLocalFlagShadowsPersistentFlag(t *testing.T) {
+	var url string
+	app := orpheus.New("testapp")
+	parent := orpheus.NewCommand("remote", "Manage remotes").
+		AddPersistentFlag("url", "", "parent", "Remote URL")
+	parent.Subcommand("set", "Set", func(ctx *orpheus.Context) error {
+		url = ctx.GetFlagString("url")
+		return nil
+	}).AddFlag("url", "", "child", "Local URL")
+	app.AddCommand(parent)
+
+	if err := app.Run([]string{"remote", "set", "--url", "x"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if url != "x" {
+		t.Errorf("url = %q, want local flag value", url)
+	}
+}
+
+func TestRequiredPersistentFlag(t *testing.T) {
+	var run remoteRun
+	app, _ := newRemoteApp(&run)
+	app.GetCommands()["remote"].MarkPersistentFlagRequired("verbose")
+
+	err := app.Run([]string{"remote", "add", "origin"})
+	requireValidationError(t, err, "required flag --verbose not provided")
+
+	if err := app.Run([]string{"remote", "add", "origin", "-v"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGlobalFlagsAfterCommandName(t *testing.T) {
+	var namespace, fromGetFlag string
+	var source orpheus.FlagSource
+	var dryRun bool
+
+	app := orpheus.New("testapp").
+		AddGlobalFlag("namespace", "n", "default", "Namespace").
+		AddGlobalBoolFlag("dry-run", "", false, "Dry run").
+		MarkGlobalFlagRequired("namespace")
+	app.AddCommand(orpheus.NewCommand("deploy", "Deploy").
+		AddFlag("image", "i", "", "Image").
+		SetHandler(func(ctx *orpheus.Context) error {
+			namespace = ctx.GetGlobalFlagString("namespace")
+			fromGetFlag = ctx.GetFlagString("namespace")
+			source = ctx.GlobalFlagSource("namespace")
+			dryRun = ctx.GetGlobalFlagBool("dry-run")
+			return nil
+		}))
+
+	if err := app.Run([]string{"deploy", "-i", "web:1", "-n", "prod", "--dry-run"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if namespace != "prod" || fromGetFlag != "prod" || !dryRun {
+		t.Errorf("namespace=%q getflag=%q dry-run=%v", namespace, fromGetFlag, dryRun)
+	}
+	if source != orpheus.FlagSourceArgs {
+		t.Errorf("source = %v, want command-line", source)
+	}
+
+	err := app.Run([]string{"deploy", "-i", "web:1"})
+	requireValidationError(t, err, "required flag --namespace not provided")
+}
+
+func TestGlobalFlagsBeforeSubcommandName(t *testing.T) {
+	var run remoteRun
+	app, _ := newRemoteApp(&run)
+	app.AddGlobalFlag("profile", "p", "", "Profile")
+
+	var profile string
+	app.GetCommands()["remote"].GetSubcommand("add").Use(func(next orpheus.CommandHandler) orpheus.CommandHandler {
+		return func(ctx *orpheus.Context) error {
+			profile = ctx.GetFlagString("profile")
+			return next(ctx)
+		}
+	})
+
+	if err := app.Run([]string{"remote", "--profile", "work", "add", "origin"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if profile != "work" {
+		t.Errorf("profile = %q", profile)
+	}
+}
+
+func TestInheritedFlagsHelp(t *testing.T) {
+	var run remoteRun
+	app, add := newRemoteApp(&run)
+
+	help := app.GetHelpGenerator().GenerateCommandHelp(add)
+	inherited := strings.Index(help, "Inherited Flags:")
+	if inherited < 0 || !strings.Contains(help[inherited:], "--verbose") || !strings.Contains(help[inherited:], "--url STRING") {
+		t.Errorf("help does not list inherited flags:\n%s", help)
+	}
+
+	parentHelp := app.GetHelpGenerator().GenerateCommandHelp(app.GetCommands()["remote"])
+	if !strings.Contains(parentHelp, "--verbose") || strings.Contains(parentHelp, "Inherited Flags:") {
+		t.Errorf("parent help should list its persistent flags as own flags:\n%s", parentHelp)
+	}
+}
@@ -0,0 +1,98 @@
+// signals.go: context propagation and signal-driven cancellation in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// forcedExitCode is the exit status used when a second signal forces termination.
+const forcedExitCode = 130
+
+// RunContext runs the application with the given parent context.
+// The context is available to handlers through Context.Context(). Unless disabled
+// with SetSignalHandling(false), SIGINT and SIGTERM cancel the context and a
+// second signal terminates the process immediately.
+func (app *App) RunContext(ctx context.Context, args []string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if !app.signalHandlingDisabled {
+		stop := app.handleSignals(ctx, cancel)
+		defer stop()
+	}
+
+	previous := app.runCtx
+	app.runCtx = ctx
+	defer func() { app.runCtx = previous }()
+
+	return app.run(args)
+}
+
+// SetSignalHandling enables or disables cancellation of the run context on SIGINT/SIGTERM.
+// Signal handling is enabled by default.
+func (app *App) SetSignalHandling(enabled bool) *App {
+	app.signalHandlingDisabled = !enabled
+	return app
+}
+
+// runContext returns the context of the current run.
+func (app *App) runContext() context.Context {
+	if app.runCtx == nil {
+		return context.Background()
+	}
+	return app.runCtx
+}
+
+// handleSignals cancels the run on the first SIGINT/SIGTERM and exits on the second.
+// It logs with ctx rather than the App's run context, which RunContext restores
+// concurrently. The returned function stops signal handling.
+func (app *App) handleSignals(ctx context.Context, cancel context.CancelFunc) func() {
+	signals := make(chan os.Signal, 2)
+	done := make(chan struct{})
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case sig := <-signals:
+			if app.logger != nil {
+				app.logger.Warn(ctx, "Received signal, cancelling command (send again to force exit)",
+					Field{Key: "signal", Value: sig.String()})
+			}
+			cancel()
+		case <-done:
+			return
+		}
+
+		select {
+		case <-signals:
+			app.exit(forcedExitCode)
+		case <-done:
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
+
+// exit terminates the process, using the test hook when set.
+func (app *App) exit(code int) {
+	if app.exitFunc != nil {
+		app.exitFunc(code)
+		return
+	}
+	os.Exit(code)
+}
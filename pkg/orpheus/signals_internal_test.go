@@ -0,0 +1,58 @@
+// signals_internal_test.go: tests for signal handling in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSignalCancelsAndForcesExit(t *testing.T) {
+	process, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Skipf("cannot signal current process: %v", err)
+	}
+
+	exitCodes := make(chan int, 1)
+	app := New("myapp")
+	app.exitFunc = func(code int) { exitCodes <- code }
+
+	var cancelErr error
+	app.Command("wait", "Wait for a signal", func(ctx *Context) error {
+		if err := process.Signal(os.Interrupt); err != nil {
+			t.Skipf("cannot send interrupt: %v", err)
+		}
+		select {
+		case <-ctx.Context().Done():
+			cancelErr = ctx.Context().Err()
+		case <-time.After(5 * time.Second):
+			t.Error("context was not cancelled by the signal")
+			return nil
+		}
+
+		// A second signal forces the exit
+		_ = process.Signal(os.Interrupt)
+		select {
+		case code := <-exitCodes:
+			if code != forcedExitCode {
+				t.Errorf("expected exit code %d, got %d", forcedExitCode, code)
+			}
+		case <-time.After(5 * time.Second):
+			t.Error("second signal did not force exit")
+		}
+		return nil
+	})
+
+	if err := app.RunContext(context.Background(), []string{"wait"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cancelErr != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", cancelErr)
+	}
+}
@@ -0,0 +1,102 @@
+// signals_test.go: tests for context propagation and cancellation in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/agilira/orpheus/pkg/orpheus"
+)
+
+type requestKey struct{}
+
+func TestRunContextPropagation(t *testing.T) {
+	var value interface{}
+	var done <-chan struct{}
+
+	app := orpheus.New("myapp")
+	remote := orpheus.NewCommand("remote", "Manage remotes")
+	remote.Subcommand("add", "Add remote", func(ctx *orpheus.Context) error {
+		value = ctx.Context().Value(requestKey{})
+		done = ctx.Context().Done()
+		return nil
+	})
+	app.AddCommand(remote)
+
+	parent := context.WithValue(context.Background(), requestKey{}, "req-1")
+	if err := app.RunContext(parent, []string{"remote", "add"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value != "req-1" {
+		t.Errorf("subcommand context should inherit parent values, got %v", value)
+	}
+
+	// The run context is cancelled once the run completes
+	select {
+	case <-done:
+	default:
+		t.Error("run context should be cancelled after Run returns")
+	}
+}
+
+func TestContextDefaultsToBackground(t *testing.T) {
+	ctx := &orpheus.Context{}
+	if ctx.Context() == nil || ctx.Context().Err() != nil {
+		t.Error("context without run should default to a live background context")
+	}
+	if ctx.Span() != nil {
+		t.Error("span should be nil without tracer")
+	}
+}
+
+func TestCommandTimeout(t *testing.T) {
+	app := orpheus.New("myapp")
+	app.AddCommand(orpheus.NewCommand("wait", "Wait for cancellation").
+		SetTimeout(20 * time.Millisecond).
+		SetHandler(func(ctx *orpheus.Context) error {
+			select {
+			case <-ctx.Context().Done():
+				return ctx.Context().Err()
+			case <-time.After(5 * time.Second):
+				return nil
+			}
+		}))
+
+	if got := app.GetCommands()["wait"].Timeout(); got != 20*time.Millisecond {
+		t.Errorf("unexpected timeout %s", got)
+	}
+
+	err := app.Run([]string{"wait"})
+	var orpheusErr *orpheus.Error
+	if !errors.As(err, &orpheusErr) || !orpheusErr.IsExecutionError() || !orpheusErr.IsRetryable() {
+		t.Fatalf("expected retryable execution error, got %v", err)
+	}
+	if orpheusErr.Error() != "command 'wait': [ORF1001]: command timed out after 20ms" {
+		t.Errorf("unexpected message: %v", orpheusErr)
+	}
+}
+
+func TestContextCarriesSpan(t *testing.T) {
+	tracer := &recordingTracer{}
+	var active orpheus.Span
+
+	app := orpheus.New("myapp").SetTracer(tracer)
+	app.Command("run", "Run", func(ctx *orpheus.Context) error {
+		active = ctx.Span()
+		return nil
+	})
+
+	if err := app.Run([]string{"run"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tracer.spans) != 1 || active != tracer.spans[0] {
+		t.Errorf("handler context should carry the command span, got %v", active)
+	}
+}
@@ -0,0 +1,171 @@
+// suggest.go: "did you mean" suggestions for unknown commands and flags in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	flashflags "github.com/agilira/flash-flags"
+)
+
+// defaultSuggestionDistance is the maximum edit distance used when none is configured.
+const defaultSuggestionDistance = 2
+
+// SetSuggestions enables or disables "did you mean" suggestions for unknown
+// commands, subcommands and flags. Suggestions are enabled by default.
+func (app *App) SetSuggestions(enabled bool) *App {
+	app.suggestionsDisabled = !enabled
+	return app
+}
+
+// SetSuggestionDistance sets the maximum edit distance for suggestions (default 2).
+// Candidates starting with the mistyped input are always suggested.
+func (app *App) SetSuggestionDistance(distance int) *App {
+	app.suggestionDistance = distance
+	return app
+}
+
+// suggestionsFor returns candidates close to input, best matches first.
+// A nil app uses the default settings.
+func (app *App) suggestionsFor(input string, candidates []string) []string {
+	maxDistance := defaultSuggestionDistance
+	if app != nil {
+		if app.suggestionsDisabled {
+			return nil
+		}
+		if app.suggestionDistance > 0 {
+			maxDistance = app.suggestionDistance
+		}
+	}
+
+	if input == "" {
+		return nil
+	}
+
+	lowerInput := strings.ToLower(input)
+	distances := make(map[string]int)
+	for _, candidate := range candidates {
+		if candidate == input {
+			continue
+		}
+		lowerCandidate := strings.ToLower(candidate)
+		distance := levenshtein(lowerInput, lowerCandidate)
+		if distance <= maxDistance || strings.HasPrefix(lowerCandidate, lowerInput) {
+			distances[candidate] = distance
+		}
+	}
+
+	suggestions := make([]string, 0, len(distances))
+	for candidate := range distances {
+		suggestions = append(suggestions, candidate)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if distances[suggestions[i]] != distances[suggestions[j]] {
+			return distances[suggestions[i]] < distances[suggestions[j]]
+		}
+		return suggestions[i] < suggestions[j]
+	})
+	return suggestions
+}
+
+// unknownCommandError builds the not found error for an unknown top-level command.
+func (app *App) unknownCommandError(cmdName string) *Error {
+	candidates := []string{"help"}
+	for _, cmd := range visibleCommands(app.commands) {
+		candidates = append(candidates, cmd.names()...)
+	}
+	return app.suggestCommand(NotFoundError(cmdName, fmt.Sprintf("command '%s' not found", cmdName)), "command", cmdName, candidates)
+}
+
+// suggestCommand attaches command suggestions to a not found error.
+func (app *App) suggestCommand(err *Error, kind, input string, candidates []string) *Error {
+	return withSuggestions(err, kind, input, app.suggestionsFor(input, candidates))
+}
+
+// flagParseError converts a flash-flags parse error into a ValidationError,
+// attaching flag suggestions when the error is caused by an unknown flag.
+func (app *App) flagParseError(command, message string, fs *flashflags.FlagSet, parseErr error) *Error {
+	name := unknownFlagName(fs, parseErr)
+	if name == "" {
+		return ValidationError(command, message+": "+parseErr.Error())
+	}
+
+	var candidates []string
+	fs.VisitAll(func(flag *flashflags.Flag) {
+		candidates = append(candidates, flag.Name())
+	})
+
+	suggestions := app.suggestionsFor(name, candidates)
+	for i, suggestion := range suggestions {
+		suggestions[i] = "--" + suggestion
+	}
+
+	err := ValidationError(command, message+": unknown flag: --"+name).WithContext("flag", name)
+	return withSuggestions(err, "flag", "--"+name, suggestions)
+}
+
+// unknownFlagName extracts the name of an unknown long flag from a parse error, or "".
+// flash-flags reports an unknown flag without a value as "flag --x requires a value".
+func unknownFlagName(fs *flashflags.FlagSet, parseErr error) string {
+	msg := parseErr.Error()
+	if name, found := strings.CutPrefix(msg, "unknown flag: --"); found {
+		return name
+	}
+	if rest, found := strings.CutPrefix(msg, "flag --"); found {
+		if name, found := strings.CutSuffix(rest, " requires a value"); found && fs.Lookup(name) == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// withSuggestions records suggestions in the error message, context and user message.
+func withSuggestions(err *Error, kind, input string, suggestions []string) *Error {
+	if len(suggestions) == 0 {
+		return err
+	}
+
+	quoted := make([]string, len(suggestions))
+	for i, suggestion := range suggestions {
+		quoted[i] = "'" + suggestion + "'"
+	}
+
+	hint := fmt.Sprintf("Did you mean %s?", quoted[0])
+	if len(quoted) > 1 {
+		hint = fmt.Sprintf("Did you mean one of %s?", strings.Join(quoted, ", "))
+	}
+
+	err.hint = hint
+	return err.WithContext("suggestions", suggestions).
+		WithUserMessage(fmt.Sprintf("Unknown %s '%s'. %s", kind, input, hint))
+}
+
+// levenshtein returns the edit distance between two strings.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(min(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
@@ -0,0 +1,120 @@
+// suggest_test.go: tests for "did you mean" suggestions in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	goerrors "github.com/agilira/go-errors"
+	"github.com/agilira/orpheus/pkg/orpheus"
+)
+
+func newSuggestApp() *orpheus.App {
+	noop := func(ctx *orpheus.Context) error { return nil }
+
+	app := orpheus.New("myapp").AddGlobalBoolFlag("verbose", "v", false, "Verbose output")
+	app.Command("status", "Show status", noop)
+	app.Command("stash", "Stash changes", noop)
+	app.AddCommand(orpheus.NewCommand("push", "Push changes").
+		AddBoolFlag("force", "f", false, "Force push").
+		SetHandler(noop))
+
+	remote := orpheus.NewCommand("remote", "Manage remotes")
+	remote.Subcommand("add", "Add remote", noop)
+	remote.Subcommand("remove", "Remove remote", noop)
+	app.AddCommand(remote)
+	return app
+}
+
+// errorContext returns the structured context attached to an orpheus error.
+func errorContext(t *testing.T, err error) map[string]interface{} {
+	t.Helper()
+
+	var goErr *goerrors.Error
+	if !errors.As(err, &goErr) {
+		t.Fatalf("expected go-errors error in chain, got %T: %v", err, err)
+	}
+	return goErr.Context
+}
+
+// requireSuggestions asserts the error carries the expected suggestions and user
+// message, and that its text ends with the same hint.
+func requireSuggestions(t *testing.T, err error, expected []string, message string) {
+	t.Helper()
+
+	var orpheusErr *orpheus.Error
+	if !errors.As(err, &orpheusErr) {
+		t.Fatalf("expected *orpheus.Error, got %T: %v", err, err)
+	}
+	if got := errorContext(t, err)["suggestions"]; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected suggestions %v, got %v", expected, got)
+	}
+	if orpheusErr.UserMessage() != message {
+		t.Errorf("expected user message %q, got %q", message, orpheusErr.UserMessage())
+	}
+	if hint := message[strings.Index(message, "Did you mean"):]; !strings.HasSuffix(err.Error(), ". "+hint) {
+		t.Errorf("expected error %q to end with %q", err.Error(), hint)
+	}
+}
+
+func TestSuggestUnknownCommand(t *testing.T) {
+	app := newSuggestApp()
+
+	err := app.Run([]string{"stauts"})
+	requireSuggestions(t, err, []string{"status"}, "Unknown command 'stauts'. Did you mean 'status'?")
+
+	err = app.Run([]string{"sta"})
+	requireSuggestions(t, err, []string{"stash", "status"}, "Unknown command 'sta'. Did you mean one of 'stash', 'status'?")
+
+	err = app.Run([]string{"deploy"})
+	var orpheusErr *orpheus.Error
+	if !errors.As(err, &orpheusErr) || !orpheusErr.IsNotFoundError() {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if _, exists := errorContext(t, err)["suggestions"]; exists {
+		t.Errorf("unexpected suggestions for unrelated input: %v", errorContext(t, err)["suggestions"])
+	}
+}
+
+func TestSuggestUnknownSubcommand(t *testing.T) {
+	err := newSuggestApp().Run([]string{"remote", "remvoe"})
+	requireSuggestions(t, err, []string{"remove"}, "Unknown subcommand 'remvoe'. Did you mean 'remove'?")
+}
+
+func TestSuggestUnknownFlag(t *testing.T) {
+	app := newSuggestApp()
+
+	err := app.Run([]string{"push", "--forse"})
+	requireSuggestions(t, err, []string{"--force"}, "Unknown flag '--forse'. Did you mean '--force'?")
+
+	err = app.Run([]string{"--verbos", "status"})
+	requireSuggestions(t, err, []string{"--verbose"}, "Unknown flag '--verbos'. Did you mean '--verbose'?")
+}
+
+func TestSuggestionsConfiguration(t *testing.T) {
+	app := newSuggestApp().SetSuggestions(false)
+
+	err := app.Run([]string{"stauts"})
+	var orpheusErr *orpheus.Error
+	if !errors.As(err, &orpheusErr) {
+		t.Fatalf("expected *orpheus.Error, got %T", err)
+	}
+	if _, exists := errorContext(t, err)["suggestions"]; exists {
+		t.Error("suggestions should be disabled")
+	}
+	if orpheusErr.UserMessage() != "Command or resource not found" {
+		t.Errorf("unexpected user message %q", orpheusErr.UserMessage())
+	}
+
+	// A larger distance finds more distant matches
+	app.SetSuggestions(true).SetSuggestionDistance(4)
+	err = app.Run([]string{"pshuu"})
+	requireSuggestions(t, err, []string{"push"}, "Unknown command 'pshuu'. Did you mean 'push'?")
+}
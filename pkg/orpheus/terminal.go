@@ -0,0 +1,157 @@
+// terminal.go: terminal-aware help output with width wrapping and colors in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// ColorMode controls ANSI colors in help output.
+type ColorMode int
+
+const (
+	// ColorNever disables colors (default)
+	ColorNever ColorMode = iota
+	// ColorAuto enables colors when the output is a terminal
+	ColorAuto
+	// ColorAlways enables colors even when the output is not a terminal
+	ColorAlways
+)
+
+// minWrapWidth is the narrowest column help text is wrapped into; narrower
+// terminals leave lines unwrapped.
+const minWrapWidth = 20
+
+// ANSI escape sequences of the help styles.
+var helpColors = map[string]string{
+	"heading": "\x1b[1m",
+	"command": "\x1b[36m",
+	"flag":    "\x1b[32m",
+}
+
+const colorReset = "\x1b[0m"
+
+// SetOutput sets where help, version and other informational output is written (default os.Stdout).
+// Terminal width and color detection apply to this writer.
+func (app *App) SetOutput(w io.Writer) *App {
+	app.output = w
+	return app
+}
+
+// SetHelpWidth sets the width help text is wrapped to. Zero (the default) uses the
+// COLUMNS environment variable or the terminal width; a negative width disables wrapping.
+func (app *App) SetHelpWidth(width int) *App {
+	app.helpWidth = width
+	return app
+}
+
+// SetColorMode enables ANSI colors for help headings, commands and flags. Unless
+// the mode is ColorNever, a --no-color global flag is added; colors are also
+// disabled when the NO_COLOR environment variable is set.
+func (app *App) SetColorMode(mode ColorMode) *App {
+	app.colorMode = mode
+	if mode != ColorNever && app.globalFlags.Lookup("no-color") == nil {
+		app.AddGlobalBoolFlag("no-color", "", false, "Disable colored output")
+	}
+	return app
+}
+
+// stdout returns the writer for help and version output.
+func (app *App) stdout() io.Writer {
+	if app == nil || app.output == nil {
+		return os.Stdout
+	}
+	return app.output
+}
+
+// terminalWidth returns the width help text is wrapped to, or 0 for no wrapping.
+func (app *App) terminalWidth() int {
+	switch {
+	case app.helpWidth > 0:
+		return app.helpWidth
+	case app.helpWidth < 0:
+		return 0
+	}
+
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	if f, ok := app.stdout().(*os.File); ok {
+		if columns, ok := terminalColumns(f); ok {
+			return columns
+		}
+	}
+	return 0
+}
+
+// colorEnabled reports whether help output is styled with ANSI colors.
+func (app *App) colorEnabled() bool {
+	if app.colorMode == ColorNever || os.Getenv("NO_COLOR") != "" || app.noColorArg {
+		return false
+	}
+	if app.globalFlags.Lookup("no-color") != nil && app.globalFlags.GetBool("no-color") {
+		return false
+	}
+	return app.colorMode == ColorAlways || isTerminal(app.stdout())
+}
+
+// isTerminal reports whether w is a character device such as a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// hasNoColorArg reports whether --no-color appears among the arguments before "--".
+func hasNoColorArg(args []string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			return false
+		}
+		if arg == "--no-color" || arg == "--no-color=true" {
+			return true
+		}
+	}
+	return false
+}
+
+// styleFuncs returns the width and color dependent template functions.
+func styleFuncs(width int, color bool) template.FuncMap {
+	return template.FuncMap{
+		"style": func(kind, s string) string {
+			if !color || s == "" || helpColors[kind] == "" {
+				return s
+			}
+			return helpColors[kind] + s + colorReset
+		},
+		"hang": func(indent int, s string) string {
+			return hangText(width, indent, s)
+		},
+		"fill": func(s string) string {
+			if width < minWrapWidth {
+				return s
+			}
+			return wrapText(width, s)
+		},
+	}
+}
+
+// hangText wraps s to the width left after indent and indents continuation lines,
+// aligning them with the first line that starts at column indent.
+func hangText(width, indent int, s string) string {
+	if width-indent < minWrapWidth {
+		return s
+	}
+	return strings.ReplaceAll(wrapText(width-indent, s), "\n", "\n"+strings.Repeat(" ", indent))
+}
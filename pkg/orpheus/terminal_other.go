@@ -0,0 +1,16 @@
+// terminal_other.go: terminal size fallback for other systems in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package orpheus
+
+import "os"
+
+// terminalColumns reports no terminal size; the COLUMNS variable still applies.
+func terminalColumns(f *os.File) (int, bool) {
+	return 0, false
+}
@@ -0,0 +1,120 @@
+// terminal_test.go: tests for terminal-aware help output in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/agilira/orpheus/pkg/orpheus"
+)
+
+func newWrapApp() (*orpheus.App, *orpheus.Command) {
+	app := orpheus.New("testapp")
+	cmd := orpheus.NewCommand("deploy", "Deploy the application").
+		AddFlag("image", "i", "latest", "Container image tag to deploy to every node of the cluster").
+		SetHandler(noop)
+	app.AddCommand(cmd)
+	return app, cmd
+}
+
+func TestHelpWrapsToColumns(t *testing.T) {
+	t.Setenv("COLUMNS", "60")
+	app, cmd := newWrapApp()
+
+	help := app.GetHelpGenerator().GenerateCommandHelp(cmd)
+	want := "  --image STRING              Container image tag to deploy\n" +
+		"                              to every node of the cluster\n" +
+		"                              (default: latest)\n"
+	if !strings.Contains(help, want) {
+		t.Errorf("expected flag description wrapped with a hanging indent:\n%s", help)
+	}
+	for _, line := range strings.Split(help, "\n") {
+		if len(line) > 60 {
+			t.Errorf("line exceeds 60 columns: %q", line)
+		}
+	}
+}
+
+func TestSetHelpWidth(t *testing.T) {
+	t.Setenv("COLUMNS", "60")
+	app, cmd := newWrapApp()
+
+	app.SetHelpWidth(-1)
+	help := app.GetHelpGenerator().GenerateCommandHelp(cmd)
+	if !strings.Contains(help, "Container image tag to deploy to every node of the cluster (default: latest)\n") {
+		t.Errorf("expected no wrapping with a negative width:\n%s", help)
+	}
+
+	app.SetHelpWidth(50)
+	help = app.GetHelpGenerator().GenerateCommandHelp(cmd)
+	if !strings.Contains(help, "  --image STRING              Container image tag\n                              to deploy to every\n") {
+		t.Errorf("expected wrapping at 50 columns:\n%s", help)
+	}
+}
+
+func TestHelpColors(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	app, cmd := newWrapApp()
+	app.SetColorMode(orpheus.ColorAlways)
+
+	help := app.GetHelpGenerator().GenerateCommandHelp(cmd)
+	for _, want := range []string{"\x1b[1mUsage:\x1b[0m", "\x1b[1mFlags:\x1b[0m", "\x1b[32m--image STRING"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("expected %q in colored help:\n%q", want, help)
+		}
+	}
+	if !strings.Contains(help, "--no-color") {
+		t.Errorf("expected --no-color global flag:\n%s", help)
+	}
+}
+
+func TestHelpColorsDisabled(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    orpheus.ColorMode
+		noColor string
+		args    []string
+	}{
+		{"NO_COLOR", orpheus.ColorAlways, "1", []string{"deploy", "--help"}},
+		{"flag", orpheus.ColorAlways, "", []string{"--no-color", "deploy", "--help"}},
+		{"non-TTY", orpheus.ColorAuto, "", []string{"deploy", "--help"}},
+		{"never", orpheus.ColorNever, "", []string{"deploy", "--help"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			var out bytes.Buffer
+			app, _ := newWrapApp()
+			app.SetOutput(&out).SetColorMode(tt.mode)
+
+			if err := app.Run(tt.args); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(out.String(), "Usage: testapp deploy") {
+				t.Fatalf("expected help on the configured output:\n%s", out.String())
+			}
+			if strings.Contains(out.String(), "\x1b[") {
+				t.Errorf("unexpected ANSI escapes:\n%q", out.String())
+			}
+		})
+	}
+}
+
+func TestSetOutputVersion(t *testing.T) {
+	var out bytes.Buffer
+	app := orpheus.New("testapp").SetVersion("1.2.3").SetOutput(&out)
+
+	if err := app.Run([]string{"--version"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.String() != "testapp version 1.2.3\n" {
+		t.Errorf("version output = %q", out.String())
+	}
+}
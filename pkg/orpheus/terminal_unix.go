@@ -0,0 +1,28 @@
+// terminal_unix.go: terminal size detection on Unix systems in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package orpheus
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// terminalColumns returns the width of the terminal attached to f, or false when
+// f is not a terminal.
+func terminalColumns(f *os.File) (int, bool) {
+	var size struct {
+		rows, cols, xpixel, ypixel uint16
+	}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&size)))
+	if errno != 0 || size.cols == 0 {
+		return 0, false
+	}
+	return int(size.cols), true
+}
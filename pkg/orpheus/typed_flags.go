@@ -0,0 +1,464 @@
+// typed_flags.go: typed flags (duration, int64, uint, byte size, maps, int slices) in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	flashflags "github.com/agilira/flash-flags"
+)
+
+// Flag kinds implemented by orpheus on top of the flash-flags storage types.
+const (
+	kindInt64     = "int64"
+	kindUint      = "uint"
+	kindByteSize  = "byteSize"
+	kindStringMap = "stringMap"
+	kindIntSlice  = "intSlice"
+	kindEnum      = "enum"
+)
+
+// byteSizeUnits maps (lowercase) byte size suffixes to their multiplier.
+var byteSizeUnits = map[string]int64{
+	"": 1, "b": 1,
+	"k": 1000, "kb": 1000, "kib": 1 << 10,
+	"m": 1000 * 1000, "mb": 1000 * 1000, "mib": 1 << 20,
+	"g": 1000 * 1000 * 1000, "gb": 1000 * 1000 * 1000, "gib": 1 << 30,
+	"t": 1000 * 1000 * 1000 * 1000, "tb": 1000 * 1000 * 1000 * 1000, "tib": 1 << 40,
+}
+
+// AddDurationFlag adds a time.Duration flag to the command (e.g. "30s", "1h30m").
+func (c *Command) AddDurationFlag(name, shorthand string, defaultValue time.Duration, description string) *Command {
+	addDurationFlag(c.flags, c.flagMeta, name, shorthand, defaultValue, description)
+	return c
+}
+
+// AddInt64Flag adds a 64-bit integer flag to the command.
+func (c *Command) AddInt64Flag(name, shorthand string, defaultValue int64, description string) *Command {
+	addInt64Flag(c.flags, c.flagMeta, name, shorthand, defaultValue, description)
+	return c
+}
+
+// AddUintFlag adds an unsigned integer flag to the command.
+func (c *Command) AddUintFlag(name, shorthand string, defaultValue uint, description string) *Command {
+	addUintFlag(c.flags, c.flagMeta, name, shorthand, defaultValue, description)
+	return c
+}
+
+// AddByteSizeFlag adds a byte size flag to the command. Values are plain byte
+// counts or use decimal (KB, MB, GB, TB) or binary (KiB, MiB, GiB, TiB) units.
+func (c *Command) AddByteSizeFlag(name, shorthand string, defaultValue int64, description string) *Command {
+	addByteSizeFlag(c.flags, c.flagMeta, name, shorthand, defaultValue, description)
+	return c
+}
+
+// AddStringMapFlag adds a key=value map flag to the command (e.g. --label env=prod,tier=web).
+func (c *Command) AddStringMapFlag(name, shorthand string, defaultValue map[string]string, description string) *Command {
+	addStringMapFlag(c.flags, c.flagMeta, name, shorthand, defaultValue, description)
+	return c
+}
+
+// AddIntSliceFlag adds a comma-separated integer list flag to the command.
+func (c *Command) AddIntSliceFlag(name, shorthand string, defaultValue []int, description string) *Command {
+	addIntSliceFlag(c.flags, c.flagMeta, name, shorthand, defaultValue, description)
+	return c
+}
+
+// AddEnumFlag adds a string flag that only accepts one of the given choices.
+// Other values are rejected during parsing; the choices are shown in help and
+// offered by completion.
+func (c *Command) AddEnumFlag(name, shorthand, defaultValue string, choices []string, description string) *Command {
+	addEnumFlag(c.flags, c.flagMeta, name, shorthand, defaultValue, choices, description)
+	return c
+}
+
+// AddGlobalDurationFlag adds a global time.Duration flag.
+func (app *App) AddGlobalDurationFlag(name, shorthand string, defaultValue time.Duration, description string) *App {
+	addDurationFlag(app.globalFlags, app.globalFlagMeta, name, shorthand, defaultValue, description)
+	return app
+}
+
+// AddGlobalInt64Flag adds a global 64-bit integer flag.
+func (app *App) AddGlobalInt64Flag(name, shorthand string, defaultValue int64, description string) *App {
+	addInt64Flag(app.globalFlags, app.globalFlagMeta, name, shorthand, defaultValue, description)
+	return app
+}
+
+// AddGlobalUintFlag adds a global unsigned integer flag.
+func (app *App) AddGlobalUintFlag(name, shorthand string, defaultValue uint, description string) *App {
+	addUintFlag(app.globalFlags, app.globalFlagMeta, name, shorthand, defaultValue, description)
+	return app
+}
+
+// AddGlobalByteSizeFlag adds a global byte size flag.
+func (app *App) AddGlobalByteSizeFlag(name, shorthand string, defaultValue int64, description string) *App {
+	addByteSizeFlag(app.globalFlags, app.globalFlagMeta, name, shorthand, defaultValue, description)
+	return app
+}
+
+// AddGlobalStringMapFlag adds a global key=value map flag.
+func (app *App) AddGlobalStringMapFlag(name, shorthand string, defaultValue map[string]string, description string) *App {
+	addStringMapFlag(app.globalFlags, app.globalFlagMeta, name, shorthand, defaultValue, description)
+	return app
+}
+
+// AddGlobalIntSliceFlag adds a global comma-separated integer list flag.
+func (app *App) AddGlobalIntSliceFlag(name, shorthand string, defaultValue []int, description string) *App {
+	addIntSliceFlag(app.globalFlags, app.globalFlagMeta, name, shorthand, defaultValue, description)
+	return app
+}
+
+// AddGlobalEnumFlag adds a global string flag that only accepts one of the given choices.
+func (app *App) AddGlobalEnumFlag(name, shorthand, defaultValue string, choices []string, description string) *App {
+	addEnumFlag(app.globalFlags, app.globalFlagMeta, name, shorthand, defaultValue, choices, description)
+	return app
+}
+
+// addDurationFlag registers a native duration flag; flash-flags has no duration shorthand,
+// so the shorthand is translated by orpheus before parsing.
+func addDurationFlag(fs *flashflags.FlagSet, meta *flagMeta, name, shorthand string, defaultValue time.Duration, description string) {
+	fs.Duration(name, defaultValue, description)
+	meta.option(name).shorthand = shorthand
+}
+
+// addFloat64Flag registers a native float64 flag with an orpheus-translated shorthand.
+func addFloat64Flag(fs *flashflags.FlagSet, meta *flagMeta, name, shorthand string, defaultValue float64, description string) {
+	fs.Float64(name, defaultValue, description)
+	meta.option(name).shorthand = shorthand
+}
+
+// addStringSliceFlag registers a native string slice flag with an orpheus-translated shorthand.
+func addStringSliceFlag(fs *flashflags.FlagSet, meta *flagMeta, name, shorthand string, defaultValue []string, description string) {
+	fs.StringSlice(name, defaultValue, description)
+	meta.option(name).shorthand = shorthand
+}
+
+// addInt64Flag registers a 64-bit integer flag stored as a validated string.
+func addInt64Flag(fs *flashflags.FlagSet, meta *flagMeta, name, shorthand string, defaultValue int64, description string) {
+	addParsedFlag(fs, meta, kindInt64, name, shorthand, strconv.FormatInt(defaultValue, 10), description, func(value string) error {
+		_, err := parseInt64(value)
+		return err
+	})
+}
+
+// addUintFlag registers an unsigned integer flag stored as a validated string.
+func addUintFlag(fs *flashflags.FlagSet, meta *flagMeta, name, shorthand string, defaultValue uint, description string) {
+	addParsedFlag(fs, meta, kindUint, name, shorthand, strconv.FormatUint(uint64(defaultValue), 10), description, func(value string) error {
+		_, err := parseUint(value)
+		return err
+	})
+}
+
+// addByteSizeFlag registers a byte size flag whose value is parsed into bytes on access.
+func addByteSizeFlag(fs *flashflags.FlagSet, meta *flagMeta, name, shorthand string, defaultValue int64, description string) {
+	addParsedFlag(fs, meta, kindByteSize, name, shorthand, formatByteSize(defaultValue), description, func(value string) error {
+		_, err := parseByteSize(value)
+		return err
+	})
+}
+
+// addEnumFlag registers a string flag restricted to choices, recorded for help and completion.
+func addEnumFlag(fs *flashflags.FlagSet, meta *flagMeta, name, shorthand, defaultValue string, choices []string, description string) {
+	allowed := append([]string(nil), choices...)
+	addParsedFlag(fs, meta, kindEnum, name, shorthand, defaultValue, description, func(value string) error {
+		// An empty default means "not set" and is always accepted
+		if value == "" && defaultValue == "" {
+			return nil
+		}
+		return checkChoice(value, allowed)
+	})
+	meta.option(name).choices = allowed
+}
+
+// addParsedFlag registers a string-backed flag whose value is checked by parse whenever it is set.
+func addParsedFlag(fs *flashflags.FlagSet, meta *flagMeta, kind, name, shorthand, defaultValue, description string, parse func(string) error) {
+	if shorthand != "" {
+		fs.StringVar(name, shorthand, defaultValue, description)
+	} else {
+		fs.String(name, defaultValue, description)
+	}
+	_ = fs.SetValidator(name, func(value interface{}) error {
+		s, _ := value.(string)
+		return parse(s)
+	})
+	meta.option(name).kind = kind
+}
+
+// addStringMapFlag registers a key=value map flag stored as a validated string slice.
+func addStringMapFlag(fs *flashflags.FlagSet, meta *flagMeta, name, shorthand string, defaultValue map[string]string, description string) {
+	keys := make([]string, 0, len(defaultValue))
+	for key := range defaultValue {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + defaultValue[key]
+	}
+
+	addParsedSliceFlag(fs, meta, kindStringMap, name, shorthand, pairs, description, func(items []string) error {
+		_, err := parseStringMap(items)
+		return err
+	})
+}
+
+// addIntSliceFlag registers an integer list flag stored as a validated string slice.
+func addIntSliceFlag(fs *flashflags.FlagSet, meta *flagMeta, name, shorthand string, defaultValue []int, description string) {
+	items := make([]string, len(defaultValue))
+	for i, value := range defaultValue {
+		items[i] = strconv.Itoa(value)
+	}
+
+	addParsedSliceFlag(fs, meta, kindIntSlice, name, shorthand, items, description, func(items []string) error {
+		_, err := parseIntSlice(items)
+		return err
+	})
+}
+
+// addParsedSliceFlag registers a string-slice-backed flag whose items are checked by parse whenever it is set.
+func addParsedSliceFlag(fs *flashflags.FlagSet, meta *flagMeta, kind, name, shorthand string, defaultValue []string, description string, parse func([]string) error) {
+	fs.StringSlice(name, defaultValue, description)
+	_ = fs.SetValidator(name, func(value interface{}) error {
+		items, _ := value.([]string)
+		return parse(items)
+	})
+	opts := meta.option(name)
+	opts.kind = kind
+	opts.shorthand = shorthand
+}
+
+// checkChoice reports an error listing the allowed choices when value is not one of them.
+func checkChoice(value string, choices []string) error {
+	for _, choice := range choices {
+		if value == choice {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid value %q, must be one of: %s", value, strings.Join(choices, ", "))
+}
+
+// parseInt64 parses a base-10 64-bit integer flag value.
+func parseInt64(value string) (int64, error) {
+	n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid int64 value %q", value)
+	}
+	return n, nil
+}
+
+// parseUint parses a base-10 unsigned integer flag value.
+func parseUint(value string) (uint, error) {
+	n, err := strconv.ParseUint(strings.TrimSpace(value), 10, strconv.IntSize)
+	if err != nil {
+		return 0, fmt.Errorf("invalid uint value %q", value)
+	}
+	return uint(n), nil
+}
+
+// parseByteSize parses sizes such as "512", "10MB", "1.5GiB" into a number of bytes.
+// Units are case-insensitive: K/M/G/T and KB/MB/GB/TB are powers of 1000,
+// KiB/MiB/GiB/TiB are powers of 1024.
+func parseByteSize(value string) (int64, error) {
+	s := strings.TrimSpace(value)
+	split := len(s)
+	for i, r := range s {
+		if (r < '0' || r > '9') && r != '.' {
+			split = i
+			break
+		}
+	}
+
+	number, unit := s[:split], strings.ToLower(strings.TrimSpace(s[split:]))
+	multiplier, ok := byteSizeUnits[unit]
+	if number == "" || !ok {
+		return 0, fmt.Errorf("invalid byte size %q", value)
+	}
+
+	if n, err := strconv.ParseInt(number, 10, 64); err == nil {
+		if n > math.MaxInt64/multiplier {
+			return 0, fmt.Errorf("byte size %q is too large", value)
+		}
+		return n * multiplier, nil
+	}
+
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte size %q", value)
+	}
+	size := f * float64(multiplier)
+	if size >= math.MaxInt64 {
+		return 0, fmt.Errorf("byte size %q is too large", value)
+	}
+	return int64(size), nil
+}
+
+// formatByteSize renders a number of bytes using the largest exact binary unit.
+func formatByteSize(size int64) string {
+	units := []struct {
+		suffix string
+		size   int64
+	}{{"TiB", 1 << 40}, {"GiB", 1 << 30}, {"MiB", 1 << 20}, {"KiB", 1 << 10}}
+
+	for _, unit := range units {
+		if size != 0 && size%unit.size == 0 {
+			return strconv.FormatInt(size/unit.size, 10) + unit.suffix
+		}
+	}
+	return strconv.FormatInt(size, 10)
+}
+
+// parseStringMap parses key=value items into a map. Later keys override earlier ones.
+func parseStringMap(items []string) (map[string]string, error) {
+	result := make(map[string]string, len(items))
+	for _, item := range items {
+		key, value, found := strings.Cut(item, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid key=value pair %q", item)
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+// parseIntSlice parses every item as a base-10 integer.
+func parseIntSlice(items []string) ([]int, error) {
+	result := make([]int, len(items))
+	for i, item := range items {
+		n, err := strconv.Atoi(strings.TrimSpace(item))
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", item)
+		}
+		result[i] = n
+	}
+	return result, nil
+}
+
+// expandShorthands rewrites shorthands that flash-flags cannot resolve (-r, -r=1.5)
+// to their long form. A trailing orpheus shorthand in a combined group (-vr) is
+// split off so that the preceding boolean shorthands are still handled by flash-flags.
+func (m *flagMeta) expandShorthands(args []string) []string {
+	if m == nil || !m.hasShorthands() {
+		return args
+	}
+
+	expanded := make([]string, 0, len(args))
+	for i, arg := range args {
+		if arg == "--" {
+			return append(expanded, args[i:]...)
+		}
+		if len(arg) < 2 || arg[0] != '-' || arg[1] == '-' {
+			expanded = append(expanded, arg)
+			continue
+		}
+
+		group, value, hasValue := strings.Cut(arg[1:], "=")
+		name := m.longName(group[len(group)-1:])
+		if name == "" {
+			expanded = append(expanded, arg)
+			continue
+		}
+
+		if len(group) > 1 {
+			expanded = append(expanded, "-"+group[:len(group)-1])
+		}
+		if hasValue {
+			expanded = append(expanded, "--"+name+"="+value)
+		} else {
+			expanded = append(expanded, "--"+name)
+		}
+	}
+	return expanded
+}
+
+// hasShorthands reports whether any flag uses an orpheus-translated shorthand.
+func (m *flagMeta) hasShorthands() bool {
+	for _, opts := range m.options {
+		if opts.shorthand != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// longName returns the flag registered with an orpheus-translated shorthand, or "".
+func (m *flagMeta) longName(shorthand string) string {
+	if m == nil {
+		return ""
+	}
+	for name, opts := range m.options {
+		if opts.shorthand != "" && opts.shorthand == shorthand {
+			return name
+		}
+	}
+	return ""
+}
+
+// flagType returns the type name of a flag as shown in help output.
+func flagType(flag *flashflags.Flag, opts *flagOptions) string {
+	if opts != nil && opts.kind != "" {
+		return opts.kind
+	}
+	return flag.Type()
+}
+
+// flagInt64 reads a 64-bit integer flag, returning 0 when unset or invalid.
+func flagInt64(fs *flashflags.FlagSet, name string) int64 {
+	if fs == nil {
+		return 0
+	}
+	n, _ := parseInt64(fs.GetString(name))
+	return n
+}
+
+// flagUint reads an unsigned integer flag, returning 0 when unset or invalid.
+func flagUint(fs *flashflags.FlagSet, name string) uint {
+	if fs == nil {
+		return 0
+	}
+	n, _ := parseUint(fs.GetString(name))
+	return n
+}
+
+// flagByteSize reads a byte size flag in bytes, returning 0 when unset or invalid.
+func flagByteSize(fs *flashflags.FlagSet, name string) int64 {
+	if fs == nil {
+		return 0
+	}
+	n, _ := parseByteSize(fs.GetString(name))
+	return n
+}
+
+// flagStringMap reads a key=value map flag, returning an empty map when unset or invalid.
+func flagStringMap(fs *flashflags.FlagSet, name string) map[string]string {
+	if fs == nil {
+		return map[string]string{}
+	}
+	m, err := parseStringMap(fs.GetStringSlice(name))
+	if err != nil {
+		return map[string]string{}
+	}
+	return m
+}
+
+// flagIntSlice reads an integer list flag, returning an empty slice when unset or invalid.
+func flagIntSlice(fs *flashflags.FlagSet, name string) []int {
+	if fs == nil {
+		return []int{}
+	}
+	values, err := parseIntSlice(fs.GetStringSlice(name))
+	if err != nil {
+		return []int{}
+	}
+	return values
+}
@@ -0,0 +1,367 @@
+// typed_flags_test.go: tests for typed flags in Orpheus application framework
+//
+// Copyright (c) 2025 AGILira - A. Giordano
+// Series: an AGILira library
+// SPDX-License-Identifier: MPL-2.0
+
+package orpheus_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agilira/orpheus/pkg/orpheus"
+)
+
+func newTypedFlagsCommand(handler orpheus.CommandHandler) *orpheus.Command {
+	return orpheus.NewCommand("serve", "Start the server").
+		AddDurationFlag("timeout", "t", 30*time.Second, "Request timeout").
+		AddInt64Flag("offset", "o", -1, "Start offset").
+		AddUintFlag("workers", "w", 4, "Worker count").
+		AddByteSizeFlag("max-body", "b", 1<<20, "Maximum body size").
+		AddStringMapFlag("label", "l", map[string]string{"tier": "web"}, "Labels").
+		AddIntSliceFlag("ports", "p", []int{80}, "Ports").
+		AddFloat64Flag("ratio", "r", 0.5, "Sampling ratio").
+		AddStringSliceFlag("tags", "g", nil, "Tags").
+		AddBoolFlag("verbose", "v", false, "Verbose output").
+		SetHandler(handler)
+}
+
+func TestTypedFlagDefaults(t *testing.T) {
+	app := orpheus.New("testapp")
+	var ctx *orpheus.Context
+	app.AddCommand(newTypedFlagsCommand(func(c *orpheus.Context) error {
+		ctx = c
+		return nil
+	}))
+
+	if err := app.Run([]string{"serve"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := ctx.GetFlagDuration("timeout"); got != 30*time.Second {
+		t.Errorf("timeout = %v, want 30s", got)
+	}
+	if got := ctx.GetFlagInt64("offset"); got != -1 {
+		t.Errorf("offset = %d, want -1", got)
+	}
+	if got := ctx.GetFlagUint("workers"); got != 4 {
+		t.Errorf("workers = %d, want 4", got)
+	}
+	if got := ctx.GetFlagByteSize("max-body"); got != 1<<20 {
+		t.Errorf("max-body = %d, want %d", got, 1<<20)
+	}
+	if got := ctx.GetFlagStringMap("label"); !reflect.DeepEqual(got, map[string]string{"tier": "web"}) {
+		t.Errorf("label = %v", got)
+	}
+	if got := ctx.GetFlagIntSlice("ports"); !reflect.DeepEqual(got, []int{80}) {
+		t.Errorf("ports = %v", got)
+	}
+}
+
+func TestTypedFlagValues(t *testing.T) {
+	app := orpheus.New("testapp")
+	var ctx *orpheus.Context
+	app.AddCommand(newTypedFlagsCommand(func(c *orpheus.Context) error {
+		ctx = c
+		return nil
+	}))
+
+	err := app.Run([]string{"serve",
+		"--timeout", "1m30s",
+		"--offset", "9000000000",
+		"--workers=16",
+		"--max-body", "10MiB",
+		"--label", "env=prod,team=core",
+		"--ports", "8080,8443",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := ctx.GetFlagDuration("timeout"); got != 90*time.Second {
+		t.Errorf("timeout = %v, want 1m30s", got)
+	}
+	if got := ctx.GetFlagInt64("offset"); got != 9000000000 {
+		t.Errorf("offset = %d", got)
+	}
+	if got := ctx.GetFlagUint("workers"); got != 16 {
+		t.Errorf("workers = %d", got)
+	}
+	if got := ctx.GetFlagByteSize("max-body"); got != 10<<20 {
+		t.Errorf("max-body = %d", got)
+	}
+	if got := ctx.GetFlagStringMap("label"); !reflect.DeepEqual(got, map[string]string{"env": "prod", "team": "core"}) {
+		t.Errorf("label = %v", got)
+	}
+	if got := ctx.GetFlagIntSlice("ports"); !reflect.DeepEqual(got, []int{8080, 8443}) {
+		t.Errorf("ports = %v", got)
+	}
+}
+
+func TestTypedFlagShorthands(t *testing.T) {
+	app := orpheus.New("testapp")
+	var ctx *orpheus.Context
+	app.AddCommand(newTypedFlagsCommand(func(c *orpheus.Context) error {
+		ctx = c
+		return nil
+	}))
+
+	err := app.Run([]string{"serve",
+		"-t", "5s",
+		"-o", "7",
+		"-w", "2",
+		"-b=2KB",
+		"-l", "a=1",
+		"-p", "1,2,3",
+		"-vr", "0.25",
+		"-g", "x,y",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := ctx.GetFlagDuration("timeout"); got != 5*time.Second {
+		t.Errorf("timeout = %v", got)
+	}
+	if got := ctx.GetFlagInt64("offset"); got != 7 {
+		t.Errorf("offset = %d", got)
+	}
+	if got := ctx.GetFlagUint("workers"); got != 2 {
+		t.Errorf("workers = %d", got)
+	}
+	if got := ctx.GetFlagByteSize("max-body"); got != 2000 {
+		t.Errorf("max-body = %d", got)
+	}
+	if got := ctx.GetFlagStringMap("label"); !reflect.DeepEqual(got, map[string]string{"a": "1"}) {
+		t.Errorf("label = %v", got)
+	}
+	if got := ctx.GetFlagIntSlice("ports"); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("ports = %v", got)
+	}
+	if !ctx.GetFlagBool("verbose") {
+		t.Error("expected -v to be set in combined group")
+	}
+	if got := ctx.GetFlagFloat64("ratio"); got != 0.25 {
+		t.Errorf("ratio = %v", got)
+	}
+	if got := ctx.GetFlagStringSlice("tags"); !reflect.DeepEqual(got, []string{"x", "y"}) {
+		t.Errorf("tags = %v", got)
+	}
+}
+
+func TestTypedFlagInvalidValues(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--offset", "abc"}, "--offset"},
+		{[]string{"--workers", "-3"}, "--workers"},
+		{[]string{"--max-body", "10 parsecs"}, "invalid byte size"},
+		{[]string{"--label", "novalue"}, "invalid key=value pair"},
+		{[]string{"--ports", "80,http"}, "invalid integer"},
+		{[]string{"--timeout", "soon"}, "--timeout"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			app := orpheus.New("testapp")
+			app.AddCommand(newTypedFlagsCommand(func(*orpheus.Context) error { return nil }))
+
+			err := app.Run(append([]string{"serve"}, tt.args...))
+			requireValidationError(t, err, tt.want)
+		})
+	}
+}
+
+func TestByteSizeUnits(t *testing.T) {
+	tests := map[string]int64{
+		"512":    512,
+		"1k":     1000,
+		"1KiB":   1024,
+		"1.5MB":  1500000,
+		"2GiB":   2 << 30,
+		"1 TiB":  1 << 40,
+		"10mib":  10 << 20,
+		"0.5KiB": 512,
+	}
+
+	for input, want := range tests {
+		t.Run(input, func(t *testing.T) {
+			app := orpheus.New("testapp")
+			var got int64
+			app.AddCommand(orpheus.NewCommand("run", "Run").
+				AddByteSizeFlag("size", "", 0, "Size").
+				SetHandler(func(ctx *orpheus.Context) error {
+					got = ctx.GetFlagByteSize("size")
+					return nil
+				}))
+
+			if err := app.Run([]string{"run", "--size", input}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != want {
+				t.Errorf("size %q = %d, want %d", input, got, want)
+			}
+		})
+	}
+}
+
+func TestTypedGlobalFlags(t *testing.T) {
+	app := orpheus.New("testapp").
+		AddGlobalDurationFlag("timeout", "t", time.Second, "Timeout").
+		AddGlobalInt64Flag("offset", "", 0, "Offset").
+		AddGlobalUintFlag("retries", "r", 3, "Retries").
+		AddGlobalByteSizeFlag("cache", "c", 0, "Cache size").
+		AddGlobalStringMapFlag("header", "H", nil, "Headers").
+		AddGlobalIntSliceFlag("codes", "", []int{200}, "Accepted status codes")
+
+	var ctx *orpheus.Context
+	app.Command("get", "Fetch", func(c *orpheus.Context) error {
+		ctx = c
+		return nil
+	})
+
+	err := app.Run([]string{"-t", "2s", "--offset", "12", "-r", "5", "-c", "1GiB", "-H", "Accept=json", "--codes", "200,204", "get"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := ctx.GetGlobalFlagDuration("timeout"); got != 2*time.Second {
+		t.Errorf("timeout = %v", got)
+	}
+	if got := ctx.GetGlobalFlagInt64("offset"); got != 12 {
+		t.Errorf("offset = %d", got)
+	}
+	if got := ctx.GetGlobalFlagUint("retries"); got != 5 {
+		t.Errorf("retries = %d", got)
+	}
+	if got := ctx.GetGlobalFlagByteSize("cache"); got != 1<<30 {
+		t.Errorf("cache = %d", got)
+	}
+	if got := ctx.GetGlobalFlagStringMap("header"); !reflect.DeepEqual(got, map[string]string{"Accept": "json"}) {
+		t.Errorf("header = %v", got)
+	}
+	if got := ctx.GetGlobalFlagIntSlice("codes"); !reflect.DeepEqual(got, []int{200, 204}) {
+		t.Errorf("codes = %v", got)
+	}
+}
+
+func TestTypedFlagsFromEnvironment(t *testing.T) {
+	t.Setenv("TESTAPP_SERVE_MAX_BODY", "4KiB")
+	t.Setenv("TESTAPP_SERVE_PORTS", "1,2")
+
+	app := orpheus.New("testapp").SetEnvPrefix("TESTAPP")
+	var ctx *orpheus.Context
+	app.AddCommand(newTypedFlagsCommand(func(c *orpheus.Context) error {
+		ctx = c
+		return nil
+	}))
+
+	if err := app.Run([]string{"serve"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := ctx.GetFlagByteSize("max-body"); got != 4096 {
+		t.Errorf("max-body = %d", got)
+	}
+	if got := ctx.GetFlagIntSlice("ports"); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("ports = %v", got)
+	}
+}
+
+func TestTypedFlagHelpShowsType(t *testing.T) {
+	app := orpheus.New("testapp")
+	cmd := newTypedFlagsCommand(func(*orpheus.Context) error { return nil })
+	app.AddCommand(cmd)
+
+	help := app.GetHelpGenerator().GenerateCommandHelp(cmd)
+	for _, want := range []string{"--max-body BYTESIZE", "(default: 1MiB)", "--label STRINGMAP", "--ports INTSLICE", "--offset INT64", "--timeout DURATION"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("help missing %q:\n%s", want, help)
+		}
+	}
+}
+
+func newEnumApp(handler orpheus.CommandHandler) *orpheus.App {
+	app := orpheus.New("testapp").
+		AddGlobalEnumFlag("log-level", "", "info", []string{"debug", "info", "warn", "error"}, "Log level")
+	app.AddCommand(orpheus.NewCommand("export", "Export data").
+		AddEnumFlag("format", "f", "json", []string{"json", "yaml", "text"}, "Output format").
+		AddEnumFlag("compress", "", "", []string{"gzip", "zstd"}, "Compression").
+		SetHandler(handler))
+	return app
+}
+
+func TestEnumFlag(t *testing.T) {
+	var format, compress, level string
+	app := newEnumApp(func(ctx *orpheus.Context) error {
+		format = ctx.GetFlagString("format")
+		compress = ctx.GetFlagString("compress")
+		level = ctx.GetGlobalFlagString("log-level")
+		return nil
+	})
+
+	if err := app.Run([]string{"export"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if format != "json" || compress != "" || level != "info" {
+		t.Errorf("defaults = %q, %q, %q", format, compress, level)
+	}
+
+	if err := app.Run([]string{"--log-level", "debug", "export", "-f", "yaml", "--compress=zstd"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if format != "yaml" || compress != "zstd" || level != "debug" {
+		t.Errorf("values = %q, %q, %q", format, compress, level)
+	}
+}
+
+func TestEnumFlagRejectsInvalidValues(t *testing.T) {
+	app := newEnumApp(func(*orpheus.Context) error { return nil })
+
+	err := app.Run([]string{"export", "--format", "xml"})
+	requireValidationError(t, err, `invalid value "xml", must be one of: json, yaml, text`)
+
+	err = app.Run([]string{"--log-level", "loud", "export"})
+	requireValidationError(t, err, "must be one of: debug, info, warn, error")
+}
+
+func TestEnumFlagHelpShowsChoices(t *testing.T) {
+	app := newEnumApp(func(*orpheus.Context) error { return nil })
+	cmd := app.GetCommands()["export"]
+
+	help := app.GetHelpGenerator().GenerateCommandHelp(cmd)
+	if !strings.Contains(help, "Output format (choices: json, yaml, text) (default: json)") {
+		t.Errorf("help does not list choices:\n%s", help)
+	}
+}
+
+func TestEnumFlagCompletion(t *testing.T) {
+	app := newEnumApp(func(*orpheus.Context) error { return nil })
+
+	tests := []struct {
+		args     []string
+		position int
+		want     []string
+	}{
+		{[]string{"export", "--format", ""}, 3, []string{"json", "yaml", "text"}},
+		{[]string{"export", "--format"}, 3, []string{"json", "yaml", "text"}},
+		{[]string{"export", "-f", "y"}, 3, []string{"yaml"}},
+		{[]string{"export", "--format=t"}, 2, []string{"--format=text"}},
+		{[]string{"export", "--log-level", "w"}, 3, []string{"warn"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			result := app.Complete(tt.args, tt.position)
+			if !reflect.DeepEqual(result.Suggestions, tt.want) {
+				t.Errorf("suggestions = %v, want %v", result.Suggestions, tt.want)
+			}
+			if result.Directive != orpheus.CompletionNoFiles {
+				t.Errorf("directive = %v, want CompletionNoFiles", result.Directive)
+			}
+		})
+	}
+}